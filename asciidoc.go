@@ -0,0 +1,271 @@
+package gin_docs
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// asciidocWriter converts the markdown of a doc to AsciiDoc
+type asciidocWriter struct {
+	src   []byte
+	ranks map[int]int
+	// Level of the section the doc is in, its headings are one level deeper
+	level int
+	// Depth of the current list
+	depth int
+}
+
+// markdownToAsciidoc converts markdown to AsciiDoc in a section of level
+func markdownToAsciidoc(md string, level int) string {
+	doc, src := parseMarkdown(md)
+	w := asciidocWriter{src: src, ranks: headingRanks(doc), level: level}
+
+	return w.blocks(doc)
+}
+
+// asciidocText escapes the markup of a text by a passthrough
+func asciidocText(s string) string {
+	if !strings.ContainsAny(s, "*_`#^~{}[]+\\") && !strings.Contains(s, "<<") && !strings.Contains(s, "((") {
+		return s
+	}
+	if !strings.Contains(s, "++") && !strings.HasSuffix(s, "+") && !strings.HasPrefix(s, "+") {
+		return "++" + s + "++"
+	}
+
+	return "pass:c[" + strings.ReplaceAll(s, "]", "\\]") + "]"
+}
+
+// asciidocLines escapes the lines which would start a block, e.g. `* text`
+func asciidocLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l != "" && strings.ContainsRune("=*-.|/[:<>'+", rune(l[0])) {
+			lines[i] = "{empty}" + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// asciidocDelimiter returns a block delimiter longer than those in the content
+func asciidocDelimiter(content string, c byte) string {
+	delim := strings.Repeat(string(c), 4)
+	for strings.Contains(content, delim) {
+		delim += string(c)
+	}
+
+	return delim
+}
+
+func (w asciidocWriter) blocks(n ast.Node) string {
+	blocks := []string{}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if b := w.block(c); b != "" {
+			blocks = append(blocks, b)
+		}
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func (w asciidocWriter) block(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.Heading:
+		level := min(w.level+1+w.ranks[n.Level], 5)
+		return "[discrete]\n" + strings.Repeat("=", level+1) + " " + w.inline(n)
+	case *ast.Paragraph, *ast.TextBlock:
+		return asciidocLines(w.inline(n))
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		code := strings.TrimSuffix(blockLines(n, w.src), "\n")
+		delim := asciidocDelimiter(code, '-')
+		style := "[source]"
+		if lang := codeLanguage(n, w.src); lang != "" {
+			style = "[source," + lang + "]"
+		}
+		return style + "\n" + delim + "\n" + code + "\n" + delim
+	case *ast.List:
+		items := []string{}
+		marker := "*"
+		if n.IsOrdered() {
+			marker = "."
+		}
+		lw := w
+		lw.depth++
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			items = append(items, lw.listItem(c, strings.Repeat(marker, lw.depth)))
+		}
+		return strings.Join(items, "\n")
+	case *ast.Blockquote:
+		content := w.blocks(n)
+		delim := asciidocDelimiter(content, '_')
+		return delim + "\n" + content + "\n" + delim
+	case *ast.ThematicBreak:
+		return "'''"
+	case *ast.HTMLBlock:
+		html := blockLines(n, w.src)
+		if n.HasClosure() {
+			html += string(n.ClosureLine.Value(w.src))
+		}
+		// The HTML is sanitized as on the page, it reaches the readers of the document
+		html = strings.TrimSpace(htmlPolicy.Sanitize(html))
+		if html == "" {
+			return ""
+		}
+		return "++++\n" + html + "\n++++"
+	case *east.Table:
+		rows := []string{}
+		for r := n.FirstChild(); r != nil; r = r.NextSibling() {
+			cells := []string{}
+			for c := r.FirstChild(); c != nil; c = c.NextSibling() {
+				cells = append(cells, "|"+strings.ReplaceAll(w.inline(c), "|", "\\|"))
+			}
+			rows = append(rows, strings.Join(cells, " "))
+		}
+		return "[options=\"header\"]\n|===\n" + strings.Join(rows, "\n") + "\n|==="
+	}
+
+	return ""
+}
+
+// listItem renders an item of a list, the blocks after its text are attached by `+`
+func (w asciidocWriter) listItem(n ast.Node, marker string) string {
+	item := marker
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c.(type) {
+		case *ast.Paragraph, *ast.TextBlock:
+			if c == n.FirstChild() {
+				item += " " + w.inline(c)
+				continue
+			}
+		case *ast.List:
+			item += "\n" + w.block(c)
+			continue
+		}
+		if c == n.FirstChild() {
+			item += " {empty}"
+		}
+		item += "\n+\n" + w.block(c)
+	}
+
+	return item
+}
+
+func (w asciidocWriter) inline(n ast.Node) string {
+	var b, text strings.Builder
+	// The texts are escaped together, so the passthroughs are not adjacent
+	flush := func() {
+		b.WriteString(asciidocText(text.String()))
+		text.Reset()
+	}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			text.Write(util.UnescapePunctuations(c.Segment.Value(w.src)))
+			if c.HardLineBreak() {
+				flush()
+				b.WriteString(" +\n")
+			} else if c.SoftLineBreak() {
+				flush()
+				b.WriteString("\n")
+			}
+			continue
+		case *ast.String:
+			text.Write(c.Value)
+			continue
+		}
+
+		flush()
+		switch c := c.(type) {
+		case *ast.CodeSpan:
+			code := plainText(c, w.src)
+			if strings.Contains(code, "+") {
+				b.WriteString("`pass:c[" + strings.ReplaceAll(code, "]", "\\]") + "]`")
+			} else {
+				b.WriteString("`+" + code + "+`")
+			}
+		case *ast.Emphasis:
+			marker := "__"
+			if c.Level == 2 {
+				marker = "**"
+			}
+			b.WriteString(marker + w.inline(c) + marker)
+		case *east.Strikethrough:
+			b.WriteString("[.line-through]#" + w.inline(c) + "#")
+		case *ast.Link:
+			b.WriteString(asciidocLink("link:", string(c.Destination), w.inline(c)))
+		case *ast.Image:
+			b.WriteString(asciidocLink("image:", string(c.Destination), plainText(c, w.src)))
+		case *ast.AutoLink:
+			url := string(c.URL(w.src))
+			if c.AutoLinkType == ast.AutoLinkEmail && !strings.HasPrefix(url, "mailto:") {
+				url = "mailto:" + url
+			}
+			b.WriteString(asciidocLink("link:", url, asciidocText(string(c.Label(w.src)))))
+		case *ast.RawHTML:
+			for i := 0; i < c.Segments.Len(); i++ {
+				seg := c.Segments.At(i)
+				if html := htmlPolicy.Sanitize(string(seg.Value(w.src))); html != "" {
+					b.WriteString("+++" + html + "+++")
+				}
+			}
+		default:
+			b.WriteString(w.inline(c))
+		}
+	}
+	flush()
+
+	return b.String()
+}
+
+// asciidocLink returns a macro, e.g. `link:https://example.com[text]`
+func asciidocLink(macro, target, text string) string {
+	if strings.ContainsAny(target, " []+") {
+		target = "++" + target + "++"
+	}
+
+	return macro + target + "[" + strings.ReplaceAll(text, "]", "\\]") + "]"
+}
+
+// asciidocExport writes the docs as an AsciiDoc document, with a section for each group
+// and an anchor for each API, e.g. `<<api-todo-addtodo>>`
+func (d ApiDoc) asciidocExport(w io.Writer) error {
+	dataMap := d.addLiveData(d.apiData())
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "= %s\n:toc: left\n:revnumber: %s\n\n", asciidocText(d.title()), d.Conf.Version)
+	if d.description() != "" {
+		b.WriteString(asciidocLines(asciidocText(d.description())) + "\n\n")
+	}
+
+	for _, group := range sortedGroups(dataMap) {
+		fmt.Fprintf(&b, "[[%s]]\n== %s\n\n", anchorId(group), asciidocText(group))
+		for _, item := range dataMap[group]["children"] {
+			fmt.Fprintf(&b, "[[%s]]\n=== %s\n\n", anchorId(group, item["name"]), asciidocText(itemTitle(item)))
+
+			b.WriteString(".url\n")
+			for _, r := range itemRoutes(item) {
+				b.WriteString("* `+" + r + "+`\n")
+			}
+			b.WriteString("\n")
+
+			if doc := d.itemPlainDoc(item); doc != "" {
+				delim := asciidocDelimiter(doc, '.')
+				b.WriteString(delim + "\n" + strings.Trim(doc, "\n") + "\n" + delim + "\n\n")
+			}
+			for _, k := range []string{"doc_md", "sample_md", "schema_md"} {
+				if item[k] != "" {
+					b.WriteString(markdownToAsciidoc(item[k], 2) + "\n\n")
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+
+	return err
+}
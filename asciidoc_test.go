@@ -0,0 +1,62 @@
+package gin_docs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToAsciidoc(t *testing.T) {
+	assert.Equal(t, "[discrete]\n==== args\n\n"+
+		"[options=\"header\"]\n|===\n|name |note\n|id |a \\| b\n|===\n\n"+
+		"[discrete]\n===== example",
+		markdownToAsciidoc("### args\n\n| name | note |\n|---|---|\n| id | a \\| b |\n\n##### example", 2))
+
+	assert.Equal(t, "See link:https://example.com[the __docs__], `+id+` and **bold**.",
+		markdownToAsciidoc("See [the *docs*](https://example.com), `id` and **bold**.", 2))
+
+	// The markup of the text is passed through
+	assert.Equal(t, "{empty}++user_id is {id} [1] and ++`pass:c[a+b]`", markdownToAsciidoc("user_id is {id} [1] and `a+b`", 2))
+	assert.Equal(t, "{empty}++* not a list++", markdownToAsciidoc("\\* not a list", 2))
+
+	assert.Equal(t, "* one\n** nested\n* two\n\n. first\n. second",
+		markdownToAsciidoc("- one\n  - nested\n- two\n\n1. first\n2. second", 2))
+	assert.Equal(t, "[source,json]\n----\n{\"a\": 1}\n----\n\n[source]\n-----\n----\n-----",
+		markdownToAsciidoc("```json\n{\"a\": 1}\n```\n\n```\n----\n```", 2))
+	assert.Equal(t, "____\nquote\n____", markdownToAsciidoc("> quote", 2))
+
+	// The raw HTML is sanitized
+	assert.Equal(t, "++++\n<details><summary>more</summary><img src=\"x.png\"></details>\n++++\n\n"+
+		"a +++<b>+++b+++</b>+++",
+		markdownToAsciidoc("<details><summary>more</summary><img src=\"x.png\" onerror=\"alert(1)\"></details>\n\n"+
+			"a <b onclick=\"alert(1)\">b</b>", 2))
+	assert.Equal(t, "", markdownToAsciidoc("<script>alert(1)</script>", 2))
+	assert.Equal(t, "a +++<img src=\"x\">+++", markdownToAsciidoc("a <img src=x onerror=alert(1)><script>", 2))
+}
+
+func TestAnchorId(t *testing.T) {
+	assert.Equal(t, "api-todo-addtodo", anchorId("Todo", "AddTodo"))
+	assert.Equal(t, "api-用户-addtodo", anchorId("用户", "AddTodo"))
+	assert.NotEqual(t, anchorId("用户"), anchorId("订单"))
+}
+
+func TestAsciidocExport(t *testing.T) {
+	r := gin.New()
+	r.POST("/todo", AddTodo)
+	r.POST("/add_data", AddData)
+	r.PATCH("/add_data", AddData)
+	c := (&Config{}).Default()
+	c.Title = "Todo API"
+	c.AllMd = false
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, ApiDoc{Ge: r, Conf: c}.Export("asciidoc", buf))
+	adoc := buf.String()
+
+	assert.Contains(t, adoc, "= Todo API\n:toc: left\n:revnumber: 1.0.0\n\n[[api-gin-docs]]\n== gin-docs\n\n")
+	assert.Contains(t, adoc, "[[api-gin-docs-adddata]]\n=== AddData(Submission of data)\n\n"+
+		".url\n* `+PATCH /add_data+`\n* `+POST /add_data+`\n\n....\nExtra notes:\n")
+	assert.Contains(t, adoc, "[[api-gin-docs-addtodo]]\n=== AddTodo(Add todo)\n\n")
+}
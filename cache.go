@@ -0,0 +1,280 @@
+package gin_docs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Smaller responses are not compressed
+	minCompressSize = 1024
+	// Bodies of `/data` are cached for this many hosts and versions at most
+	maxDataCacheSize = 16
+
+	pageCacheControl   = "no-cache"
+	dataCacheControl   = "private, no-cache"
+	staticCacheControl = "public, max-age=86400"
+)
+
+// cachedContent is a rendered response with its hash and compressed variants
+type cachedContent struct {
+	contentType string
+	modTime     time.Time
+	etag        string
+	body        []byte
+
+	once     sync.Once
+	gzipBody []byte
+	brBody   []byte
+}
+
+func newCachedContent(contentType string, body []byte, modTime time.Time) *cachedContent {
+	sum := sha256.Sum256(body)
+
+	return &cachedContent{
+		contentType: contentType,
+		modTime:     modTime,
+		etag:        hex.EncodeToString(sum[:8]),
+		body:        body,
+	}
+}
+
+func (cc *cachedContent) compressible() bool {
+	if len(cc.body) < minCompressSize {
+		return false
+	}
+	for _, t := range []string{"text/", "javascript", "json", "svg", "xml"} {
+		if strings.Contains(cc.contentType, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compress computes the compressed variants once
+func (cc *cachedContent) compress() {
+	cc.once.Do(func() {
+		var buf bytes.Buffer
+		gw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if _, err := gw.Write(cc.body); err == nil && gw.Close() == nil {
+			cc.gzipBody = bytes.Clone(buf.Bytes())
+		}
+
+		buf.Reset()
+		bw := brotli.NewWriterLevel(&buf, brotli.DefaultCompression)
+		if _, err := bw.Write(cc.body); err == nil && bw.Close() == nil {
+			cc.brBody = bytes.Clone(buf.Bytes())
+		}
+	})
+}
+
+// serve writes the content in the encoding accepted by the client,
+// and answers conditional requests with `304 Not Modified`
+func (cc *cachedContent) serve(c *gin.Context, cacheControl string) {
+	body, encoding := cc.body, ""
+	if cc.compressible() {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding = acceptEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding != "" {
+			cc.compress()
+		}
+		switch {
+		case encoding == "br" && cc.brBody != nil:
+			body = cc.brBody
+		case encoding == "gzip" && cc.gzipBody != nil:
+			body = cc.gzipBody
+		default:
+			encoding = ""
+		}
+	}
+
+	etag := cc.etag
+	if encoding != "" {
+		etag += "-" + encoding
+		c.Header("Content-Encoding", encoding)
+	}
+	c.Header("ETag", `"`+etag+`"`)
+	c.Header("Cache-Control", cacheControl)
+	c.Header("Content-Type", cc.contentType)
+
+	http.ServeContent(c.Writer, c.Request, "", cc.modTime, bytes.NewReader(body))
+}
+
+// acceptEncoding returns the preferred encoding of `br` and `gzip` in an `Accept-Encoding` header
+func acceptEncoding(header string) string {
+	// Encodings with `q=0` are refused
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		accepted[name] = true
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil && q == 0 {
+				accepted[name] = false
+			}
+		}
+	}
+
+	for _, encoding := range []string{"br", "gzip"} {
+		if ok, listed := accepted[encoding]; (listed && ok) || (!listed && accepted["*"]) {
+			return encoding
+		}
+	}
+
+	return ""
+}
+
+// pageContent returns the rendered page, it is rendered again after the templates are reloaded
+func (d ApiDoc) pageContent() (*cachedContent, error) {
+	s := d.state()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.page == nil {
+		docMu.RLock()
+		render := d.renderHtml
+		if !d.isVueUi() {
+			render = d.renderUi
+		}
+		htmlStr, err := render()
+		docMu.RUnlock()
+		if err != nil {
+			return nil, err
+		}
+		s.page = newCachedContent("text/html; charset=utf-8", []byte(htmlStr), time.Now())
+	}
+
+	return s.page, nil
+}
+
+// dataH returns the body of `/data`, the markdown of the APIs is rendered by `addHtml`
+func (d ApiDoc) dataH(host string, v DocVersion, dataMap DataMap) (gin.H, error) {
+	dataMap, err := addHtml(dataMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return gin.H{
+		"PROJECT_NAME":    PROJECT_NAME,
+		"PROJECT_VERSION": PROJECT_VERSION,
+		"host":            host,
+		"title":           d.title(),
+		"version":         v.Name,
+		"versions":        d.versionNames(),
+		"services":        d.serviceNames(),
+		"description":     d.description(),
+		"lang":            d.locale(),
+		"langs":           d.locales(),
+		"noDocText":       d.Conf.NoDocText,
+		"data":            dataMap,
+		"liveReload":      gin.IsDebugging(),
+	}, nil
+}
+
+func (d ApiDoc) dataBody(host string, v DocVersion, dataMap DataMap) ([]byte, error) {
+	h, err := d.dataH(host, v, dataMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(h)
+}
+
+// dataContent returns the body of `/data` of a version in the locale, it is cached until
+// the API data changes unless recorded samples or inferred schemas are shown
+func (d ApiDoc) dataContent(host string, v DocVersion) (*cachedContent, error) {
+	if d.Conf.Samples != nil || d.Conf.Schemas != nil {
+		dataMap, err := d.versionData(v, d.apiData())
+		if err != nil {
+			return nil, err
+		}
+		if v.DataFile == "" {
+			dataMap = d.addLiveData(dataMap)
+		}
+		body, err := d.dataBody(host, v, dataMap)
+		if err != nil {
+			return nil, err
+		}
+		return newCachedContent("application/json; charset=utf-8", body, time.Time{}), nil
+	}
+
+	s := d.state()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	live := d.loadApiData(s)
+	key := v.Name + "\n" + d.Conf.lang + "\n" + host
+	if cc := s.data[key]; cc != nil {
+		return cc, nil
+	}
+
+	dataMap, err := d.versionData(v, live)
+	if err != nil {
+		return nil, err
+	}
+	body, err := d.dataBody(host, v, dataMap)
+	if err != nil {
+		return nil, err
+	}
+	if s.data == nil || len(s.data) >= maxDataCacheSize {
+		s.data = make(map[string]*cachedContent)
+	}
+	s.data[key] = newCachedContent("application/json; charset=utf-8", body, time.Now())
+
+	return s.data[key], nil
+}
+
+type staticFile struct {
+	modTime time.Time
+	content *cachedContent
+}
+
+var staticMap sync.Map
+
+// serveStatic serves the bundled assets from memory with their compressed variants
+func serveStatic(c *gin.Context) {
+	name := path.Clean("/" + c.Param("filepath"))
+	file := filepath.Join(rootPath, "static", filepath.FromSlash(name))
+
+	info, err := os.Stat(file)
+	if err != nil || info.IsDir() {
+		c.Status(http.StatusNotFound)
+		return
+	}
+
+	// Files changed on disk are read again
+	if v, ok := staticMap.Load(file); ok && v.(staticFile).modTime.Equal(info.ModTime()) {
+		v.(staticFile).content.serve(c, staticCacheControl)
+		return
+	}
+
+	body, err := os.ReadFile(file)
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return
+	}
+	contentType := mime.TypeByExtension(filepath.Ext(file))
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+
+	cc := newCachedContent(contentType, body, info.ModTime())
+	staticMap.Store(file, staticFile{modTime: info.ModTime(), content: cc})
+	cc.serve(c, staticCacheControl)
+}
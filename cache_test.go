@@ -0,0 +1,112 @@
+package gin_docs
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcceptEncoding(t *testing.T) {
+	for header, want := range map[string]string{
+		"":                         "",
+		"identity":                 "",
+		"gzip, deflate":            "gzip",
+		"gzip, deflate, br":        "br",
+		"br;q=0, gzip;q=0.5":       "gzip",
+		"*":                        "br",
+		"br;q=0, *":                "gzip",
+		"GZIP;q=1.0":               "gzip",
+		"gzip;q=0, br;q=0, *;q=1":  "",
+		"deflate, gzip;q=0.000001": "gzip",
+	} {
+		assert.Equal(t, want, acceptEncoding(header), header)
+	}
+}
+
+func TestOnlineHtmlConditional(t *testing.T) {
+	r := setupRouter()
+	assert.NoError(t, setupOnlineHtml(r))
+
+	for _, url := range []string{"/docs/api/", "/docs/api/data"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		etag := w.Header().Get("ETag")
+		assert.NotEmpty(t, etag)
+		assert.Contains(t, w.Header().Get("Cache-Control"), "no-cache")
+		assert.NotEmpty(t, w.Header().Get("Last-Modified"))
+
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", url, nil)
+		req.Header.Set("If-None-Match", etag)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+	}
+}
+
+func TestOnlineHtmlCompression(t *testing.T) {
+	r := setupRouter()
+	assert.NoError(t, setupOnlineHtml(r))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/", nil)
+	r.ServeHTTP(w, req)
+	page := w.Body.String()
+	etag := w.Header().Get("ETag")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/docs/api/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	gr, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(gr)
+	assert.Equal(t, page, string(body))
+}
+
+func TestServeStatic(t *testing.T) {
+	r := setupRouter()
+	assert.NoError(t, setupOnlineHtml(r))
+
+	name := "element-ui-2.15.6.min.js"
+	raw, err := os.ReadFile(filepath.Join(rootPath, "static", "js", name))
+	assert.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/static/js/"+name, nil)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+	assert.Less(t, w.Body.Len(), len(raw)/3)
+	body, _ := io.ReadAll(brotli.NewReader(w.Body))
+	assert.Equal(t, raw, body)
+
+	etag := w.Header().Get("ETag")
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/docs/api/static/js/"+name, nil)
+	req.Header.Set("Accept-Encoding", "gzip, br")
+	req.Header.Set("If-None-Match", etag)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+
+	for _, url := range []string{"/docs/api/static/js/missing.js", "/docs/api/static/js", "/docs/api/static/../gin_docs.go"} {
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("GET", url, nil)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code, url)
+	}
+}
@@ -0,0 +1,17 @@
+// Lint Gin-Docs handler doc comments
+//
+//	go install github.com/kwkwc/gin-docs/cmd/gin-docs-lint@latest
+//	gin-docs-lint ./...
+//	gin-docs-lint -fix ./...
+//	go vet -vettool=$(which gin-docs-lint) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/kwkwc/gin-docs/docslint"
+)
+
+func main() {
+	singlechecker.Main(docslint.Analyzer)
+}
@@ -0,0 +1,370 @@
+package gin_docs
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+)
+
+const baseUrlVar = "base_url"
+
+// collectionRequest is a request of the Insomnia and Bruno collections, `.http` files and HAR
+type collectionRequest struct {
+	Group string
+	Name  string
+	// First line of the doc
+	Summary string
+	Doc     string
+	Method  string
+	// Path of the route, e.g. `/todo/:id`
+	Route string
+	// Path with the params as variables, e.g. `/todo/{{id}}`
+	Path string
+	// Request `json` example of the doc
+	Body string
+	// Response `json` example of the doc
+	Response string
+}
+
+// pathVars replaces the path params by variables in the format of `tmpl`,
+// e.g. `/todo/:id` is `/todo/{{id}}` with `{{%s}}`
+func pathVars(path, tmpl string) (string, []string) {
+	vars := []string{}
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+			vars = append(vars, s[1:])
+			segments[i] = fmt.Sprintf(tmpl, s[1:])
+		}
+	}
+
+	return strings.Join(segments, "/"), vars
+}
+
+// docExample returns the first `json` example under the `request` or `response` heading of a doc
+func docExample(doc, section string) string {
+	lines := []docLine{}
+	for _, text := range strings.Split(doc, "\n") {
+		lines = append(lines, docLine{Text: text})
+	}
+	for _, example := range jsonExamples(lines) {
+		if example.section == section {
+			return strings.TrimSpace(example.body)
+		}
+	}
+
+	return ""
+}
+
+// collectionRequests returns the requests by group, the groups are sorted,
+// an API of several routes has a request for each of them
+func (d ApiDoc) collectionRequests(tmpl string) ([]string, map[string][]collectionRequest, []string) {
+	dataMap := d.apiData()
+	groups := make([]string, 0, len(dataMap))
+	for group := range dataMap {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	requests := map[string][]collectionRequest{}
+	allVars := []string{}
+	for _, group := range groups {
+		for _, item := range dataMap[group]["children"] {
+			routes := apiRoutes(item)
+			for _, r := range routes {
+				// The requests of the routes of a handler are told apart by the method, then the path
+				name := item["name"]
+				if len(routes) > 1 {
+					name += " " + r.Method
+				}
+				if slices.ContainsFunc(routes, func(o apiRoute) bool {
+					return o.Method == r.Method && o.Path != r.Path
+				}) {
+					name += " " + r.Path
+				}
+				path, vars := pathVars(r.Path, tmpl)
+				for _, v := range vars {
+					if !slices.Contains(allVars, v) {
+						allVars = append(allVars, v)
+					}
+				}
+				doc := item["doc_md"]
+				if doc == "" {
+					doc = item["doc"]
+				}
+				requests[group] = append(requests[group], collectionRequest{
+					Group:    group,
+					Name:     name,
+					Summary:  item["name_extra"],
+					Doc:      strings.TrimSpace(item["name_extra"] + "\n\n" + doc),
+					Method:   r.Method,
+					Route:    r.Path,
+					Path:     path,
+					Body:     docExample(doc, "request"),
+					Response: docExample(doc, "response"),
+				})
+			}
+		}
+	}
+	sort.Strings(allVars)
+
+	return groups, requests, allVars
+}
+
+func (d ApiDoc) baseUrl() string {
+	if d.Conf.ApiHost != "" {
+		return d.Conf.ApiHost
+	}
+
+	return "http://127.0.0.1"
+}
+
+// collectionId returns a stable id, so the exports of the same routes are the same
+func collectionId(prefix string, parts ...string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+
+	return prefix + "_" + hex.EncodeToString(sum[:8])
+}
+
+// insomniaExport writes the docs as an Insomnia v4 export, with a workspace,
+// its base environment of `base_url` and the path params, and a folder for each group
+func (d ApiDoc) insomniaExport(w io.Writer) error {
+	groups, requests, vars := d.collectionRequests("{{ _.%s }}")
+
+	workspaceId := collectionId("wrk", d.Conf.Title)
+	envData := map[string]string{baseUrlVar: d.baseUrl()}
+	for _, v := range vars {
+		envData[v] = ""
+	}
+	resources := []map[string]any{
+		{
+			"_id":         workspaceId,
+			"_type":       "workspace",
+			"parentId":    nil,
+			"name":        d.title(),
+			"description": d.description(),
+			"scope":       "collection",
+		},
+		{
+			"_id":      collectionId("env", d.Conf.Title),
+			"_type":    "environment",
+			"parentId": workspaceId,
+			"name":     "Base Environment",
+			"data":     envData,
+		},
+	}
+	for _, group := range groups {
+		groupId := collectionId("fld", group)
+		resources = append(resources, map[string]any{
+			"_id":         groupId,
+			"_type":       "request_group",
+			"parentId":    workspaceId,
+			"name":        group,
+			"description": "",
+		})
+		for _, r := range requests[group] {
+			body, headers := map[string]string{}, []map[string]string{}
+			if r.Body != "" {
+				body = map[string]string{"mimeType": "application/json", "text": r.Body}
+				headers = append(headers, map[string]string{"name": "Content-Type", "value": "application/json"})
+			}
+			resources = append(resources, map[string]any{
+				"_id":         collectionId("req", group, r.Method, r.Path),
+				"_type":       "request",
+				"parentId":    groupId,
+				"name":        r.Name,
+				"description": r.Doc,
+				"method":      r.Method,
+				"url":         "{{ _." + baseUrlVar + " }}" + r.Path,
+				"body":        body,
+				"headers":     headers,
+				"parameters":  []any{},
+			})
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(map[string]any{
+		"_type":           "export",
+		"__export_format": 4,
+		"__export_source": PROJECT_NAME + ":" + PROJECT_VERSION,
+		"resources":       resources,
+	})
+}
+
+// bruBlock returns a block of a `.bru` file, its lines are indented by 2 spaces
+func bruBlock(name string, lines ...string) string {
+	b := name + " {\n"
+	for _, l := range lines {
+		if l == "" {
+			b += "\n"
+			continue
+		}
+		b += "  " + l + "\n"
+	}
+
+	return b + "}\n"
+}
+
+// safeFileName returns a file name without the characters not allowed in paths
+func safeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+}
+
+// brunoFiles returns the files of a Bruno collection by their paths, with an environment
+// of `base_url` and the path params, and a folder for each group
+func (d ApiDoc) brunoFiles() (map[string]string, error) {
+	groups, requests, vars := d.collectionRequests("{{%s}}")
+
+	collection, err := json.MarshalIndent(map[string]any{
+		"version": "1",
+		"name":    d.title(),
+		"type":    "collection",
+		"ignore":  []string{"node_modules", ".git"},
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	envVars := []string{baseUrlVar + ": " + d.baseUrl()}
+	for _, v := range vars {
+		envVars = append(envVars, v+": ")
+	}
+	files := map[string]string{
+		"bruno.json":               string(collection) + "\n",
+		"environments/Default.bru": bruBlock("vars", envVars...),
+	}
+
+	for i, group := range groups {
+		dir := safeFileName(group)
+		files[dir+"/folder.bru"] = bruBlock("meta", "name: "+group, fmt.Sprintf("seq: %d", i+1))
+		// The file names of a folder are unique, so no request overwrites another
+		used := map[string]bool{"folder": true}
+		for j, r := range requests[group] {
+			bodyType := "none"
+			if r.Body != "" {
+				bodyType = "json"
+			}
+			bru := bruBlock("meta", "name: "+r.Name, "type: http", fmt.Sprintf("seq: %d", j+1)) + "\n" +
+				bruBlock(strings.ToLower(r.Method),
+					"url: {{"+baseUrlVar+"}}"+r.Path,
+					"body: "+bodyType,
+					"auth: none",
+				)
+			if r.Body != "" {
+				bru += "\n" + bruBlock("body:json", strings.Split(r.Body, "\n")...)
+			}
+			if r.Doc != "" {
+				bru += "\n" + bruBlock("docs", strings.Split(r.Doc, "\n")...)
+			}
+			files[dir+"/"+uniqueSlug(safeFileName(r.Name), used)+".bru"] = bru
+		}
+	}
+
+	return files, nil
+}
+
+// brunoZip writes the Bruno collection as a zip archive
+func (d ApiDoc) brunoZip(w io.Writer) error {
+	files, err := d.brunoFiles()
+	if err != nil {
+		return err
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	zw := zip.NewWriter(w)
+	for _, p := range paths {
+		f, err := zw.Create(p)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, files[p]); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func (d ApiDoc) OfflineInsomnia(out string, force bool) (err error) {
+	if out == "" {
+		out = "insomnia.json"
+	}
+
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	dest := filepath.Join(".", out)
+	if ok, _ := pathExists(dest); ok {
+		if !force {
+			return fmt.Errorf("target `%s` exists, set `force=true` to override.", dest)
+		}
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.insomniaExport(f)
+}
+
+func (d ApiDoc) OfflineBruno(out string, force bool) (err error) {
+	if out == "" {
+		out = "bruno"
+	}
+
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	files, err := d.brunoFiles()
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(".", out)
+	if ok, _ := pathExists(dest); ok {
+		if !force {
+			return fmt.Errorf("target `%s` exists, set `force=true` to override.", dest)
+		}
+		if err := os.RemoveAll(dest); err != nil {
+			return err
+		}
+	}
+
+	for p, content := range files {
+		filePath := filepath.Join(dest, filepath.FromSlash(p))
+		if err := os.MkdirAll(filepath.Dir(filePath), os.ModePerm); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+			return err
+		}
+	}
+
+	return
+}
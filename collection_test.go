@@ -0,0 +1,141 @@
+package gin_docs
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCollection() ApiDoc {
+	r := gin.New()
+	r.POST("/todo", AddTodo)
+	r.GET("/todo/:id", GetTodo)
+	r.PUT("/todo/:id", GetTodo)
+	r.GET("/files/*path", GetData)
+
+	c := (&Config{}).Default()
+	c.Title = "Todo API"
+	c.ApiHost = "http://127.0.0.1:8080"
+	c.GroupBy = GroupByPath(1)
+
+	return ApiDoc{Ge: r, Conf: c}
+}
+
+func TestPathVars(t *testing.T) {
+	path, vars := pathVars("/todo/:id/files/*path", "{{%s}}")
+	assert.Equal(t, "/todo/{{id}}/files/{{path}}", path)
+	assert.Equal(t, []string{"id", "path"}, vars)
+}
+
+func TestInsomniaExport(t *testing.T) {
+	apiDoc := setupCollection()
+	buf := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("insomnia", buf))
+
+	export := struct {
+		Type      string           `json:"_type"`
+		Format    int              `json:"__export_format"`
+		Resources []map[string]any `json:"resources"`
+	}{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &export))
+	assert.Equal(t, "export", export.Type)
+	assert.Equal(t, 4, export.Format)
+
+	byType := map[string][]map[string]any{}
+	for _, r := range export.Resources {
+		byType[r["_type"].(string)] = append(byType[r["_type"].(string)], r)
+	}
+	assert.Equal(t, "Todo API", byType["workspace"][0]["name"])
+	assert.Equal(t, map[string]any{"base_url": "http://127.0.0.1:8080", "id": "", "path": ""},
+		byType["environment"][0]["data"])
+	assert.Len(t, byType["request_group"], 2)
+	assert.Len(t, byType["request"], 4)
+
+	requests := map[string]map[string]any{}
+	for _, r := range byType["request"] {
+		requests[r["name"].(string)] = r
+	}
+	assert.Equal(t, "{{ _.base_url }}/todo/{{ _.id }}", requests["GetTodo GET"]["url"])
+	assert.Equal(t, "PUT", requests["GetTodo PUT"]["method"])
+	addTodo := requests["AddTodo"]
+	assert.Equal(t, "POST", addTodo["method"])
+	assert.Contains(t, addTodo["body"].(map[string]any)["text"], `"name": "xx"`)
+	assert.Equal(t, byType["request_group"][1]["_id"], addTodo["parentId"])
+
+	// The export is stable
+	buf2 := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("insomnia", buf2))
+	assert.Equal(t, buf.String(), buf2.String())
+}
+
+func TestBrunoExport(t *testing.T) {
+	apiDoc := setupCollection()
+	assert.NoError(t, apiDoc.init())
+	files, err := apiDoc.brunoFiles()
+	assert.NoError(t, err)
+
+	assert.Contains(t, files["bruno.json"], `"name": "Todo API"`)
+	assert.Equal(t, "vars {\n  base_url: http://127.0.0.1:8080\n  id: \n  path: \n}\n", files["environments/Default.bru"])
+	assert.Equal(t, "meta {\n  name: todo\n  seq: 2\n}\n", files["todo/folder.bru"])
+	assert.Contains(t, files["todo/GetTodo PUT.bru"], "put {\n  url: {{base_url}}/todo/{{id}}\n  body: none\n  auth: none\n}\n")
+	assert.Contains(t, files["todo/AddTodo.bru"], "body:json {\n  {\"id\": 1.5")
+	assert.Contains(t, files["todo/AddTodo.bru"], "docs {\n  Add todo\n")
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("bruno", buf))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, zr.File, len(files))
+}
+
+// folder is a handler named like the file of a Bruno folder
+func folder(c *gin.Context) {}
+
+func TestBrunoUniqueNames(t *testing.T) {
+	r := gin.New()
+	r.GET("/a", GetData)
+	r.GET("/b", GetData)
+	r.POST("/b", GetData)
+	r.GET("/folder", folder)
+	apiDoc := ApiDoc{Ge: r, Conf: (&Config{}).Default()}
+	assert.NoError(t, apiDoc.init())
+
+	_, requests, _ := apiDoc.collectionRequests("{{%s}}")
+	names := []string{}
+	for _, r := range requests["gin-docs"] {
+		names = append(names, r.Name)
+	}
+	assert.ElementsMatch(t, []string{"GetData GET /a", "GetData GET /b", "GetData POST", "folder"}, names)
+
+	files, err := apiDoc.brunoFiles()
+	assert.NoError(t, err)
+	assert.Contains(t, files["gin-docs/GetData GET -a.bru"], "url: {{base_url}}/a\n")
+	assert.Contains(t, files["gin-docs/GetData GET -b.bru"], "url: {{base_url}}/b\n")
+	assert.Contains(t, files["gin-docs/GetData POST.bru"], "post {")
+	assert.Contains(t, files["gin-docs/folder-2.bru"], "name: folder\n")
+	assert.Equal(t, "meta {\n  name: gin-docs\n  seq: 1\n}\n", files["gin-docs/folder.bru"])
+}
+
+func TestOfflineBruno(t *testing.T) {
+	apiDoc := setupCollection()
+	out := "bruno_test"
+	defer os.RemoveAll(out)
+	assert.NoError(t, apiDoc.OfflineBruno(out, false))
+	assert.FileExists(t, filepath.Join(out, "todo", "AddTodo.bru"))
+	assert.Error(t, apiDoc.OfflineBruno(out, false))
+	assert.NoError(t, apiDoc.OfflineBruno(out, true))
+
+	dest := "insomnia_test.json"
+	defer os.Remove(dest)
+	assert.NoError(t, apiDoc.OfflineInsomnia(dest, false))
+	data, err := os.ReadFile(dest)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"__export_format": 4`)
+	assert.Error(t, apiDoc.OfflineInsomnia(dest, false))
+}
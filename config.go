@@ -7,40 +7,102 @@ type Config struct {
 	Version string
 	// Description
 	Description string
+	// Locale of the docs in the comments, default `en`
+	Locale string
+	// Other locales of the docs, the `@@@lang:xx` blocks of the comments or `<Handler>.<lang>.md` files
+	Locales []DocLocale
 
 	// Custom CDN CSS Template
 	CdnCssTemplate string
 	// Custom CDN JS Template
 	CdnJsTemplate string
+	// Theme of the page, the logo, colors, dark mode, extra CSS/JS and templates overriding its blocks
+	Theme Theme
 
 	// Custom url prefix, default `/docs/api`
 	UrlPrefix string
+	// Host of the documented API used by the debugger, e.g. when the docs are
+	// served on another port, default the host of the page
+	ApiHost string
+	// Url the static site of `OfflineSite` is published at, for its `sitemap.xml`
+	SiteUrl string
 	// No document text, default `No documentation found for this API`
 	NoDocText string
 	// Enable document pages, default `true`
 	Enable bool
 	// Using CDN, default `false`
 	Cdn bool
+	// UI of the docs page, `vue`, `redoc`, `swagger-ui`, `scalar` or `rapidoc`, default `vue`
+	Ui string
+	// Directory of the `<lang>.json` message catalogs of the page, in addition to the bundled
+	// `en`, `zh`, `ja`, `ko`, `de`, `fr` and `es`
+	UiLocaleDir string
 	// API package name to exclude
 	Exclude []string
 	// Methods allowed to be displayed, default `[]string{"GET", "POST", "PUT", "DELETE", "PATCH"}`
 	MethodsList []string
-	// SHA256 encrypted authorization password, e.g. here is admin
+	// SHA256 encrypted authorization password in lowercase hex, e.g. here is admin
 	// echo -n admin | shasum -a 256
 	// `8c6976e5b5410415bde908bd4dee15dfb167a9c873fc4bb8a81f6f2ab448a918`
 	PasswordSha2 string
 	// Enable markdown processing for all documents, default `true`
 	AllMd bool
+	// Store of traffic recorded by `RecordSamples`, shown as API examples
+	Samples SampleStore
+	// Number of recorded samples shown for each API, default `3`
+	SampleLimit int
+	// Store of JSON schemas inferred by `InferSchemas`, shown on API pages
+	Schemas *SchemaStore
+	// Typed request and response models of handlers, their `json` examples are validated at startup
+	Models []Model
+	// API versions of the version switcher, the first is the default, default `nil` only shows `Version`
+	Versions []DocVersion
+	// Services aggregated by the portal, default `nil`
+	Services []DocService
+	// Group of a route in the navigation, default `GroupByPackage`
+	GroupBy GroupBy
+	// Exporters by name, in addition to those of `RegisterExporter`
+	Exporters map[string]Exporter
+
+	// Locale of a copy made by `ApiDoc.Locale`, empty for `Locale`, and the config it is copied from
+	lang string
+	base *Config
+}
+
+// root returns the config a copy of `ApiDoc.Locale` is made from, their docs share the state
+func (c *Config) root() *Config {
+	if c.base != nil {
+		return c.base
+	}
+
+	return c
 }
 
+// Default sets the fields not set to their default, `Enable` and `AllMd` are always set to `true`
 func (c *Config) Default() *Config {
-	c.Title = "API Doc"
-	c.Version = "1.0.0"
-	c.UrlPrefix = "/docs/api"
-	c.NoDocText = "No documentation found for this API"
-	c.MethodsList = []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
+	if c.Title == "" {
+		c.Title = "API Doc"
+	}
+	if c.Version == "" {
+		c.Version = "1.0.0"
+	}
+	if c.Locale == "" {
+		c.Locale = "en"
+	}
+	if c.UrlPrefix == "" {
+		c.UrlPrefix = "/docs/api"
+	}
+	if c.NoDocText == "" {
+		c.NoDocText = "No documentation found for this API"
+	}
+	if len(c.MethodsList) == 0 {
+		c.MethodsList = []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
+	}
 	c.Enable = true
 	c.AllMd = true
+	if c.SampleLimit == 0 {
+		c.SampleLimit = 3
+	}
 
 	return c
 }
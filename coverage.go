@@ -0,0 +1,286 @@
+package gin_docs
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kwkwc/gin-docs/internal/doclint"
+)
+
+var headingRegexp = doclint.HeadingRegexp
+
+// Sections of a document whose headings are aliases of each other
+var sectionAliases = doclint.SectionAliases
+
+// docSections splits a markdown document into the contents under each heading,
+// keyed by the lowercased heading text
+func docSections(md string) map[string]string {
+	sections := map[string]string{}
+	name := ""
+	inFence := false
+	for _, line := range strings.Split(md, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence {
+			if m := headingRegexp.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+				name = strings.ToLower(m[2])
+				sections[name] += ""
+				continue
+			}
+		}
+		if name != "" {
+			sections[name] += line + "\n"
+		}
+	}
+
+	return sections
+}
+
+func docSection(sections map[string]string, name string) string {
+	for _, alias := range sectionAliases[name] {
+		if content, ok := sections[alias]; ok {
+			return content
+		}
+	}
+
+	return ""
+}
+
+type RouteCoverage struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Group   string `json:"group"`
+	Handler string `json:"handler"`
+	// Has a doc comment
+	Doc bool `json:"doc"`
+	// Has a summary line
+	Summary bool `json:"summary"`
+	// Has an `args` table
+	Args bool `json:"args"`
+	// Has a `request` example
+	Request bool `json:"request"`
+	// Has a `response` example
+	Response bool `json:"response"`
+}
+
+func (rc RouteCoverage) checks() []bool {
+	return []bool{rc.Doc, rc.Summary, rc.Args, rc.Request, rc.Response}
+}
+
+// Missing returns the names of the missing parts
+func (rc RouteCoverage) Missing() []string {
+	missing := []string{}
+	for i, ok := range rc.checks() {
+		if !ok {
+			missing = append(missing, coverageChecks[i])
+		}
+	}
+
+	return missing
+}
+
+var coverageChecks = []string{"doc", "summary", "args", "request", "response"}
+
+type CoverageStats struct {
+	Routes   int `json:"routes"`
+	Doc      int `json:"doc"`
+	Summary  int `json:"summary"`
+	Args     int `json:"args"`
+	Request  int `json:"request"`
+	Response int `json:"response"`
+	// Ratio of the passed checks, between 0 and 1
+	Ratio float64 `json:"ratio"`
+}
+
+func (cs *CoverageStats) add(rc RouteCoverage) {
+	cs.Routes++
+	for i, n := range []*int{&cs.Doc, &cs.Summary, &cs.Args, &cs.Request, &cs.Response} {
+		if rc.checks()[i] {
+			*n++
+		}
+	}
+	cs.Ratio = float64(cs.Doc+cs.Summary+cs.Args+cs.Request+cs.Response) /
+		float64(cs.Routes*len(coverageChecks))
+}
+
+type Coverage struct {
+	Routes []RouteCoverage           `json:"routes"`
+	Groups map[string]*CoverageStats `json:"groups"`
+	Total  CoverageStats             `json:"total"`
+}
+
+// Coverage reports which parts of the documentation each route has
+func (d ApiDoc) Coverage() (Coverage, error) {
+	coverage := Coverage{
+		Routes: []RouteCoverage{},
+		Groups: map[string]*CoverageStats{},
+	}
+
+	if err := d.init(); err != nil {
+		return coverage, err
+	}
+
+	// The docs are parsed again by the live reload
+	docMu.RLock()
+	defer docMu.RUnlock()
+
+	for _, r := range d.Ge.Routes() {
+		if d.isDocRoute(r.Path) || !slices.Contains(d.Conf.MethodsList, r.Method) {
+			continue
+		}
+		pkgName, funcName := d.splitHandler(r.Handler)
+		if slices.Contains(d.Conf.Exclude, pkgName) {
+			continue
+		}
+
+		docSrc := d.getApiDoc(r.HandlerFunc, funcName)
+		nameExtra, doc, docMd := d.splitDoc(docSrc)
+		if docMd == "" {
+			docMd = doc
+		}
+		sections := docSections(docMd)
+
+		rc := RouteCoverage{
+			Method:   r.Method,
+			Path:     r.Path,
+			Group:    d.routeGroup(r),
+			Handler:  funcName,
+			Doc:      strings.TrimSpace(docSrc) != "",
+			Summary:  nameExtra != "",
+			Args:     strings.Contains(docSection(sections, "args"), "|"),
+			Request:  strings.Contains(docSection(sections, "request"), "```"),
+			Response: strings.Contains(docSection(sections, "response"), "```"),
+		}
+		coverage.Routes = append(coverage.Routes, rc)
+
+		if coverage.Groups[rc.Group] == nil {
+			coverage.Groups[rc.Group] = &CoverageStats{}
+		}
+		coverage.Groups[rc.Group].add(rc)
+		coverage.Total.add(rc)
+	}
+
+	sort.Slice(coverage.Routes, func(i, j int) bool {
+		a, b := coverage.Routes[i], coverage.Routes[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Path != b.Path {
+			return a.Path < b.Path
+		}
+		return a.Method < b.Method
+	})
+
+	return coverage, nil
+}
+
+// isDocRoute reports whether path belongs to the documentation pages themselves
+func (d ApiDoc) isDocRoute(path string) bool {
+	for _, prefix := range append(d.mountPaths(), d.Conf.UrlPrefix) {
+		if path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteReport writes the routes with missing parts and the ratio of each group
+func (c Coverage) WriteReport(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "ROUTE\tHANDLER\tMISSING")
+	for _, rc := range c.Routes {
+		if missing := rc.Missing(); len(missing) > 0 {
+			fmt.Fprintf(tw, "%s %s\t%s.%s\t%s\n",
+				rc.Method, rc.Path, rc.Group, rc.Handler, strings.Join(missing, ", "))
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	groups := make([]string, 0, len(c.Groups))
+	for g := range c.Groups {
+		groups = append(groups, g)
+	}
+	sort.Strings(groups)
+
+	fmt.Fprintln(tw, "GROUP\tROUTES\tCOVERAGE")
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f%%\n", g, c.Groups[g].Routes, c.Groups[g].Ratio*100)
+	}
+	fmt.Fprintf(tw, "total\t%d\t%.1f%%\n", c.Total.Routes, c.Total.Ratio*100)
+
+	return tw.Flush()
+}
+
+// CheckCoverage writes the coverage report to w and returns an error below threshold
+func (d ApiDoc) CheckCoverage(threshold float64, w io.Writer) error {
+	coverage, err := d.Coverage()
+	if err != nil {
+		return err
+	}
+
+	if err := coverage.WriteReport(w); err != nil {
+		return err
+	}
+
+	// An engine without routes has nothing to document
+	if coverage.Total.Routes > 0 && coverage.Total.Ratio < threshold {
+		return fmt.Errorf(
+			"documentation coverage %.1f%% is below `%.1f%%`",
+			coverage.Total.Ratio*100, threshold*100,
+		)
+	}
+
+	return nil
+}
+
+// CoverageFlag defines the `-docs-coverage` flag on fs, see `ApiDoc.ExitOnCoverage`
+func CoverageFlag(fs *flag.FlagSet) *float64 {
+	return fs.Float64(
+		"docs-coverage", 0,
+		"check the documentation coverage and exit, non-zero below this ratio (0-1)",
+	)
+}
+
+// ExitOnCoverage checks the coverage and exits when threshold is greater than 0,
+// with status 1 if it is below threshold
+func (d ApiDoc) ExitOnCoverage(threshold float64) {
+	if threshold <= 0 {
+		return
+	}
+
+	if err := d.CheckCoverage(threshold, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "%s err: %s\n", PROJECT_NAME, err)
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// TestingT is the subset of `testing.TB` used by the test helpers
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...any)
+	FailNow()
+}
+
+// RequireCoverage fails the test when the documentation coverage is below threshold
+func RequireCoverage(t TestingT, d ApiDoc, threshold float64) {
+	t.Helper()
+
+	var report strings.Builder
+	if err := d.CheckCoverage(threshold, &report); err != nil {
+		t.Errorf("%s\n%s", err, report.String())
+		t.FailNow()
+	}
+}
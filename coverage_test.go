@@ -0,0 +1,92 @@
+package gin_docs
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Get data
+
+### args
+| args | required | location | type | help |
+|------|----------|----------|------|------|
+| id   | true     | query    | int  | id   |
+
+### request
+```
+/get_data?id=1
+```
+
+### response
+```json
+{"data": null}
+```
+*/
+func GetData(c *gin.Context) {
+	c.JSON(http.StatusOK, nil)
+}
+
+type fakeT struct {
+	errors []string
+	failed bool
+}
+
+func (f *fakeT) Helper() {}
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+func (f *fakeT) FailNow() { f.failed = true }
+
+func TestCoverage(t *testing.T) {
+	r := setupRouter()
+	r.GET("/get_data", GetData)
+	assert.NoError(t, setupOnlineHtml(r))
+
+	c := (&Config{}).Default()
+	coverage, err := ApiDoc{Ge: r, Conf: c}.Coverage()
+	assert.NoError(t, err)
+
+	routes := map[string]RouteCoverage{}
+	for _, rc := range coverage.Routes {
+		routes[rc.Method+" "+rc.Path] = rc
+	}
+	assert.NotContains(t, routes, "GET /docs/api/")
+	assert.Equal(t, []string{"args", "request", "response"}, routes["POST /add_data"].Missing())
+	assert.Equal(t, []string{"summary", "args", "request", "response"}, routes["DELETE /delete_data"].Missing())
+	assert.Equal(t, []string{"doc", "summary", "args", "request", "response"}, routes["PUT /change_data"].Missing())
+	assert.Equal(t, []string{}, routes["GET /get_data"].Missing())
+
+	assert.Equal(t, 7, coverage.Total.Routes)
+	assert.Equal(t, 6, coverage.Total.Doc)
+	assert.Equal(t, 7, coverage.Groups["gin-docs"].Routes)
+}
+
+func TestRequireCoverage(t *testing.T) {
+	r := setupRouter()
+	apiDoc := ApiDoc{Ge: r, Conf: (&Config{}).Default()}
+
+	ft := &fakeT{}
+	RequireCoverage(ft, apiDoc, 0.9)
+	assert.True(t, ft.failed)
+	assert.Contains(t, ft.errors[0], "is below `90.0%`")
+	assert.Contains(t, ft.errors[0], "PUT /change_data")
+
+	ft = &fakeT{}
+	RequireCoverage(ft, apiDoc, 0.1)
+	assert.False(t, ft.failed)
+
+	var report strings.Builder
+	assert.NoError(t, apiDoc.CheckCoverage(0, &report))
+	assert.Contains(t, report.String(), "total")
+
+	// An engine without routes passes
+	ft = &fakeT{}
+	RequireCoverage(ft, ApiDoc{Ge: gin.New(), Conf: (&Config{}).Default()}, 0.9)
+	assert.False(t, ft.failed)
+}
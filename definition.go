@@ -1,5 +1,11 @@
 package gin_docs
 
+import (
+	"go/ast"
+	"go/token"
+	"sync"
+)
+
 const (
 	PROJECT_NAME    = "Gin-Docs"
 	PROJECT_VERSION = Version
@@ -23,8 +29,22 @@ var templateMap = KVMap{
 	"css_template_local": "",
 	"js_template_cdn":    "",
 	"js_template_local":  "",
+	"site":               "",
+	"ui":                 "",
 }
 
 var docMap = make(map[string]KVMap)
 
-var pkgMap = make(map[string][]string)
+type docDecl struct {
+	fset *token.FileSet
+	doc  *ast.CommentGroup
+}
+
+var docDeclMap = make(map[string]map[string]docDecl)
+
+// pkgMap is written by `splitHandler` under `docMu` held for reading, or not held at all,
+// so it has a lock of its own
+var (
+	pkgMu  sync.Mutex
+	pkgMap = make(map[string][]string)
+)
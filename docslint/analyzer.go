@@ -0,0 +1,123 @@
+// Package docslint provides a `go vet` style analyzer for Gin-Docs handler doc comments.
+package docslint
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/kwkwc/gin-docs/internal/doclint"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "gindocs",
+	Doc:  "check the markdown documentation in Gin handler doc comments",
+	Run:  run,
+}
+
+var routeMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH", "HEAD", "OPTIONS", "Any", "Handle"}
+
+// routePaths collects the literal paths of the handlers registered in the package,
+// e.g. `r.GET("/todo/:id", GetTodo)`
+func routePaths(pass *analysis.Pass) map[types.Object][]string {
+	paths := map[types.Object][]string{}
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			sel, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !slices.Contains(routeMethods, sel.Sel.Name) {
+				return true
+			}
+
+			args := call.Args
+			if sel.Sel.Name == "Handle" && len(args) > 0 {
+				args = args[1:]
+			}
+			if len(args) < 2 {
+				return true
+			}
+			lit, ok := args[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return true
+			}
+			path, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return true
+			}
+
+			for _, arg := range args[1:] {
+				ident, ok := arg.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				if obj := pass.TypesInfo.Uses[ident]; obj != nil && !slices.Contains(paths[obj], path) {
+					paths[obj] = append(paths[obj], path)
+				}
+			}
+			return true
+		})
+	}
+
+	return paths
+}
+
+func isGinHandler(pass *analysis.Pass, fn *ast.FuncDecl) bool {
+	obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+	if !ok {
+		return false
+	}
+	params := obj.Type().(*types.Signature).Params()
+
+	return params.Len() == 1 &&
+		params.At(0).Type().String() == "*github.com/gin-gonic/gin.Context"
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	paths := routePaths(pass)
+
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Pos())
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+			obj := pass.TypesInfo.Defs[fn.Name]
+			if _, registered := paths[obj]; !registered && !isGinHandler(pass, fn) {
+				continue
+			}
+
+			// Literal paths may lack the prefixes of router groups
+			for _, dg := range doclint.LintComment(pass.Fset, fn.Doc, paths[obj], true) {
+				fixes := []analysis.SuggestedFix{}
+				if len(dg.Fixes) > 0 {
+					edits := []analysis.TextEdit{}
+					for _, e := range dg.Fixes {
+						edits = append(edits, analysis.TextEdit{
+							Pos:     tokFile.Pos(e.Start),
+							End:     tokFile.Pos(e.End),
+							NewText: []byte(e.NewText),
+						})
+					}
+					fixes = append(fixes, analysis.SuggestedFix{Message: "fix " + dg.Rule, TextEdits: edits})
+				}
+
+				pass.Report(analysis.Diagnostic{
+					Pos:            tokFile.Pos(dg.Pos.Offset),
+					Category:       dg.Rule,
+					Message:        dg.Message,
+					SuggestedFixes: fixes,
+				})
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,40 @@
+package todo
+
+import "github.com/gin-gonic/gin"
+
+func routes(r *gin.Engine) {
+	r.GET("/todo/:id/:tag", GetTodo)
+	r.GET("/todo", ListTodo)
+}
+
+// The path params are checked against the literal routes, which may lack
+// the prefixes of router groups, so `name` is not reported
+// want +8 "missing summary line before `### args`"
+// want +8 "route param `:tag` is missing from the args table"
+// want +8 "table row has 3 columns, header has 4"
+// want +11 "heading level skips from h3 to h5"
+// want +12 "invalid JSON in `json` block"
+// want +13 "`@@@` block is not closed"
+
+/*
+### args
+| args | required | location | type |
+|------|----------|----------|
+| id   | true     | path     | int  |
+| name | true     | path     | string |
+
+##### request
+```json
+{"code": xxxx}
+```
+@@@
+*/
+func GetTodo(c *gin.Context) {}
+
+// List todos
+func ListTodo(c *gin.Context) {}
+
+// helper is not a handler, its doc is not checked
+//
+// ##### request
+func helper() {}
@@ -3,6 +3,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,12 +14,24 @@ import (
 )
 
 func main() {
+	// go run examples/sample_app.go -docs-coverage 0.9
+	docsCoverage := gd.CoverageFlag(flag.CommandLine)
+	flag.Parse()
+
 	r := gin.Default()
 	r.POST("/api/todo", AddTodo)
 	r.GET("/api/todo", GetTodo)
 
 	c := &gd.Config{}
-	apiDoc := gd.ApiDoc{Ge: r, Conf: c.Default()}
+	c = c.Default()
+	// Validate the `json` examples against the models at startup
+	c.Models = []gd.Model{
+		{Handler: AddTodo, Request: Todo{}, Response: Response{}},
+		{Handler: GetTodo, Response: Response{}},
+	}
+	apiDoc := gd.ApiDoc{Ge: r, Conf: c}
+	apiDoc.ExitOnCoverage(*docsCoverage)
+
 	err := apiDoc.OnlineHtml()
 	if err != nil {
 		fmt.Printf("Gin-Docs err: %s\n", err)
@@ -32,6 +45,17 @@ func main() {
 	}
 }
 
+type Todo struct {
+	Name string `json:"name" binding:"required"`
+	Type string `json:"type" binding:"required"`
+}
+
+type Response struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+	Data any    `json:"data"`
+}
+
 /*
 Add todo
 
@@ -48,7 +72,7 @@ Add todo
 
 ### response
 ```json
-{"code": xxxx, "msg": "xxx", "data": null}
+{"code": 0, "msg": "ok", "data": null}
 ```
 */
 func AddTodo(c *gin.Context) {
@@ -76,7 +100,7 @@ http://127.0.0.1:8080/api/todo?name=xxx&type=code
 
 ### response
 ```json
-{"code": xxxx, "msg": "xxx", "data": null}
+{"code": 0, "msg": "ok", "data": null}
 ```
 */
 func GetTodo(c *gin.Context) {
@@ -0,0 +1,205 @@
+package gin_docs
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Exporter writes the docs in a format
+type Exporter struct {
+	// e.g. `text/markdown; charset=utf-8`
+	ContentType string
+	// File extension, e.g. `.md`
+	Ext    string
+	Export func(w io.Writer, d ApiDoc) error
+}
+
+var (
+	exporterMu  sync.RWMutex
+	exporterMap = map[string]Exporter{
+		"markdown": {
+			ContentType: "text/markdown; charset=utf-8",
+			Ext:         ".md",
+			Export: func(w io.Writer, d ApiDoc) error {
+				_, err := io.WriteString(w, d.renderMarkdown(d.addLiveData(d.apiData())))
+				return err
+			},
+		},
+		"json": {
+			ContentType: "application/json; charset=utf-8",
+			Ext:         ".json",
+			Export: func(w io.Writer, d ApiDoc) error {
+				v, err := d.findVersion("")
+				if err != nil {
+					return err
+				}
+				body, err := d.dataBody("http://127.0.0.1", v, d.addLiveData(d.apiData()))
+				if err != nil {
+					return err
+				}
+				_, err = w.Write(body)
+				return err
+			},
+		},
+		"openapi": {
+			ContentType: "application/json; charset=utf-8",
+			Ext:         ".openapi.json",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.openApiExport(w)
+			},
+		},
+		"asciidoc": {
+			ContentType: "text/asciidoc; charset=utf-8",
+			Ext:         ".adoc",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.asciidocExport(w)
+			},
+		},
+		"rst": {
+			ContentType: "text/x-rst; charset=utf-8",
+			Ext:         ".rst",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.rstExport(w)
+			},
+		},
+		"insomnia": {
+			ContentType: "application/json; charset=utf-8",
+			Ext:         ".insomnia.json",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.insomniaExport(w)
+			},
+		},
+		"http": {
+			ContentType: "text/plain; charset=utf-8",
+			Ext:         ".http",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.httpFileExport(w)
+			},
+		},
+		"har": {
+			ContentType: "application/json; charset=utf-8",
+			Ext:         ".har",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.harExport(w)
+			},
+		},
+		"bruno": {
+			ContentType: "application/zip",
+			Ext:         ".bruno.zip",
+			Export: func(w io.Writer, d ApiDoc) error {
+				return d.brunoZip(w)
+			},
+		},
+	}
+)
+
+// RegisterExporter adds an exporter for all ApiDocs, the exporter of the same name is replaced
+func RegisterExporter(name string, e Exporter) {
+	exporterMu.Lock()
+	defer exporterMu.Unlock()
+
+	exporterMap[name] = e
+}
+
+// exporter returns the exporter by name, those of the config first
+func (d ApiDoc) exporter(name string) (Exporter, error) {
+	if e, ok := d.Conf.Exporters[name]; ok {
+		return e, nil
+	}
+
+	exporterMu.RLock()
+	defer exporterMu.RUnlock()
+	if e, ok := exporterMap[name]; ok {
+		return e, nil
+	}
+
+	return Exporter{}, fmt.Errorf("exporter `%s` does not exist", name)
+}
+
+// ExporterNames returns the names of the exporters available
+func (d ApiDoc) ExporterNames() []string {
+	names := []string{}
+	for name := range d.Conf.Exporters {
+		names = append(names, name)
+	}
+
+	exporterMu.RLock()
+	for name := range exporterMap {
+		if _, ok := d.Conf.Exporters[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	exporterMu.RUnlock()
+	sort.Strings(names)
+
+	return names
+}
+
+// Export writes the docs by the exporter of the name
+func (d ApiDoc) Export(name string, w io.Writer) error {
+	e, err := d.exporter(name)
+	if err != nil {
+		return err
+	}
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	return e.Export(w, d)
+}
+
+// exportFileName returns the file name of an export, e.g. `api-doc.md`
+func (d ApiDoc) exportFileName(e Exporter) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '.' || r == '_':
+			return unicode.ToLower(r)
+		case r == '-' || unicode.IsSpace(r):
+			return '-'
+		}
+		return -1
+	}, d.Conf.Title)
+	if name == "" {
+		name = "api-doc"
+	}
+
+	return name + e.Ext
+}
+
+// serveExport serves `/export/:name` as a download, `/export` lists the exporters
+func (d ApiDoc) serveExport(c *gin.Context) {
+	name := strings.Trim(c.Param("name"), "/")
+	if name == "" {
+		c.JSON(http.StatusOK, gin.H{"exporters": d.ExporterNames()})
+		return
+	}
+
+	e, err := d.exporter(name)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	buf := &bytes.Buffer{}
+	if err := e.Export(buf, d); err != nil {
+		slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": d.exportFileName(e)}))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,55 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	csv := Exporter{
+		ContentType: "text/csv",
+		Ext:         ".csv",
+		Export: func(w io.Writer, d ApiDoc) error {
+			for _, item := range d.apiData()["gin-docs"]["children"] {
+				if _, err := io.WriteString(w, item["name"]+","+item["url"]+"\n"); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	apiDoc, err := New(r, WithExporter("csv", csv))
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("csv", buf))
+	assert.Equal(t, "AddData,/add_data\t[POST]\n", buf.String())
+
+	buf.Reset()
+	assert.NoError(t, apiDoc.Export("markdown", buf))
+	assert.Contains(t, buf.String(), "AddData")
+
+	buf.Reset()
+	assert.NoError(t, apiDoc.Export("json", buf))
+	data := map[string]any{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+	assert.Contains(t, data["data"], "gin-docs")
+
+	assert.EqualError(t, apiDoc.Export("pdf", buf), "exporter `pdf` does not exist")
+
+	RegisterExporter("pdf", csv)
+	defer func() {
+		exporterMu.Lock()
+		delete(exporterMap, "pdf")
+		exporterMu.Unlock()
+	}()
+	assert.Equal(t, []string{"asciidoc", "bruno", "csv", "har", "http", "insomnia", "json", "markdown", "openapi", "pdf", "rst"}, apiDoc.ExporterNames())
+	assert.NoError(t, apiDoc.Export("pdf", buf))
+}
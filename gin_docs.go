@@ -7,8 +7,10 @@ import (
 	"go/parser"
 	"go/token"
 	"log/slog"
+	"maps"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"runtime"
@@ -26,6 +28,15 @@ type ApiDoc struct {
 }
 
 func (d ApiDoc) init() (err error) {
+	// A zero config would quietly disable the docs
+	if reflect.ValueOf(*d.Conf).IsZero() {
+		slog.Warn(fmt.Sprintf("%s: the config is empty, the defaults are used\n", PROJECT_NAME))
+		d.Conf.Default()
+	}
+
+	docMu.Lock()
+	defer docMu.Unlock()
+
 	rootPath = d.getRootPath()
 	if err := d.readTemplate(rootPath); err != nil {
 		return err
@@ -37,6 +48,16 @@ func (d ApiDoc) init() (err error) {
 }
 
 func (d ApiDoc) OnlineHtml() (err error) {
+	return d.OnlineHtmlOn(d.Ge)
+}
+
+// OnlineHtmlOn mounts the docs on a router, e.g. a group with its own middleware
+// or a separate engine served on an internal port, at `UrlPrefix` under its base path
+func (d ApiDoc) OnlineHtmlOn(router gin.IRouter) (err error) {
+	if router == nil {
+		return fmt.Errorf("`OnlineHtmlOn` router is nil")
+	}
+
 	if err := d.init(); err != nil {
 		return err
 	}
@@ -45,37 +66,151 @@ func (d ApiDoc) OnlineHtml() (err error) {
 		return
 	}
 
-	dataMap := d.getApiData()
+	// The configs which are not loaded by `LoadConfig` or `New` are checked for mistakes only
+	if err := d.Conf.Validate(); err != nil {
+		slog.Warn(fmt.Sprintf("%s: %s\n", PROJECT_NAME, err))
+	}
+
+	if len(d.Conf.Models) > 0 {
+		d.logInvalidExamples()
+	}
+
+	if err := checkUiAssets(d.Conf.Ui, d.Conf.Cdn); err != nil {
+		return err
+	}
+	if err := d.checkLocales(); err != nil {
+		return err
+	}
+	// The templates of the theme are checked before the docs are mounted
+	if _, err := d.pageContent(); err != nil {
+		return err
+	}
+
+	docs := router.Group(d.Conf.UrlPrefix)
+	mountPath := d.Conf.UrlPrefix
+	if g, ok := router.(interface{ BasePath() string }); ok {
+		mountPath = path.Join(g.BasePath(), d.Conf.UrlPrefix)
+	}
+	d.addMount(mountPath)
 
-	d.Ge.Static(d.Conf.UrlPrefix+"/static", filepath.Join(rootPath, "static"))
+	docs.GET("/static/*filepath", serveStatic)
+	docs.HEAD("/static/*filepath", serveStatic)
 
-	d.Ge.GET(d.Conf.UrlPrefix+"/", func(c *gin.Context) {
-		c.Header("Content-Type", "text/html; charset=utf-8")
-		c.String(http.StatusOK, d.renderHtml())
+	docs.GET("/", func(c *gin.Context) {
+		cc, err := d.pageContent()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		cc.serve(c, pageCacheControl)
 	})
 
-	d.Ge.GET(d.Conf.UrlPrefix+"/data",
+	docs.GET("/data",
+		verifyPassword(d.Conf.PasswordSha2),
+		func(c *gin.Context) {
+			d := d.requestLocale(c)
+			host := d.Conf.ApiHost
+			if host == "" {
+				referer := c.Request.Header.Get("referer")
+				if referer == "" {
+					referer = "http://127.0.0.1"
+				}
+				host = strings.Split(referer, mountPath)[0]
+			}
+
+			v, err := d.findVersion(c.Query("version"))
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			if len(d.Conf.Services) > 0 {
+				d.servePortal(c, host, v)
+				return
+			}
+			cc, err := d.dataContent(host, v)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			cc.serve(c, dataCacheControl)
+		})
+
+	docs.GET("/openapi.json",
+		verifyPassword(d.Conf.PasswordSha2),
+		func(c *gin.Context) {
+			c.Header("Content-Type", "application/json; charset=utf-8")
+			if err := d.requestLocale(c).openApiExport(c.Writer); err != nil {
+				slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+			}
+		})
+
+	docs.GET("/search",
+		verifyPassword(d.Conf.PasswordSha2),
+		func(c *gin.Context) {
+			d.requestLocale(c).serveSearch(c)
+		})
+
+	docs.GET("/export/*name",
+		verifyPassword(d.Conf.PasswordSha2),
+		func(c *gin.Context) {
+			d.requestLocale(c).serveExport(c)
+		})
+
+	docs.GET("/diff",
 		verifyPassword(d.Conf.PasswordSha2),
 		func(c *gin.Context) {
-			urlPrefix := d.Conf.UrlPrefix
-			referer := c.Request.Header.Get("referer")
-			if referer == "" {
-				referer = "http://127.0.0.1"
+			diff, err := d.Diff(c.Query("from"), c.Query("to"))
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
 			}
-			host := strings.Split(referer, urlPrefix)[0]
-
-			c.JSON(http.StatusOK, gin.H{
-				"PROJECT_NAME":    PROJECT_NAME,
-				"PROJECT_VERSION": PROJECT_VERSION,
-				"host":            host,
-				"title":           d.Conf.Title,
-				"version":         d.Conf.Version,
-				"description":     d.Conf.Description,
-				"noDocText":       d.Conf.NoDocText,
-				"data":            dataMap,
-			})
+			switch c.Query("format") {
+			case "md":
+				c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(diff.Markdown()))
+				return
+			case "html":
+				h, err := markdownToHtml(diff.Markdown())
+				if err != nil {
+					c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+					return
+				}
+				c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(h))
+				return
+			}
+			c.JSON(http.StatusOK, diff)
 		})
 
+	docs.GET("/schema",
+		verifyPassword(d.Conf.PasswordSha2),
+		func(c *gin.Context) {
+			if d.Conf.Schemas == nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Schema inference is not enabled"})
+				return
+			}
+
+			method, path, part := c.Query("method"), c.Query("path"), c.DefaultQuery("part", "response")
+			if c.Query("format") == "go" {
+				goStruct, err := d.Conf.Schemas.GoStruct(method, path, part, c.Query("name"))
+				if err != nil {
+					c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+					return
+				}
+				c.String(http.StatusOK, goStruct)
+				return
+			}
+
+			schema, err := d.Conf.Schemas.JSONSchema(method, path, part)
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+			c.Data(http.StatusOK, "application/schema+json; charset=utf-8", schema)
+		})
+
+	if gin.IsDebugging() {
+		d.liveReload(docs)
+	}
+
 	return
 }
 
@@ -88,16 +223,23 @@ func (d ApiDoc) OfflineHtml(out string, force bool) (err error) {
 		return err
 	}
 
-	htmlStr := d.renderHtml()
+	htmlStr, err := d.renderHtml()
+	if err != nil {
+		return err
+	}
 
-	dataMap := d.getApiData()
+	dataMap, err := addHtml(d.addLiveData(d.apiData()))
+	if err != nil {
+		return err
+	}
 	data := gin.H{
 		"PROJECT_NAME":    PROJECT_NAME,
 		"PROJECT_VERSION": PROJECT_VERSION,
 		"host":            "http://127.0.0.1",
-		"title":           d.Conf.Title,
+		"title":           d.title(),
 		"version":         d.Conf.Version,
-		"description":     d.Conf.Description,
+		"description":     d.description(),
+		"lang":            d.locale(),
 		"noDocText":       d.Conf.NoDocText,
 		"data":            dataMap,
 	}
@@ -148,7 +290,7 @@ func (d ApiDoc) OfflineMarkdown(out string, force bool) (err error) {
 		return err
 	}
 
-	dataMap := d.getApiData()
+	dataMap := d.addLiveData(d.apiData())
 
 	dest := filepath.Join(".", out)
 	if ok, _ := pathExists(dest); ok {
@@ -157,8 +299,25 @@ func (d ApiDoc) OfflineMarkdown(out string, force bool) (err error) {
 		}
 	}
 
-	md := ""
+	md := d.renderMarkdown(dataMap)
+
+	if err := os.WriteFile(dest, []byte(md), 0644); err != nil {
+		return err
+	}
+
+	return
+}
+
+// renderMarkdown renders the docs of all APIs as one markdown document
+func (d ApiDoc) renderMarkdown(dataMap DataMap) string {
+	fullNames := make([]string, 0, len(dataMap))
 	for fullName := range dataMap {
+		fullNames = append(fullNames, fullName)
+	}
+	sort.Strings(fullNames)
+
+	md := ""
+	for _, fullName := range fullNames {
 		md += "# " + fullName + "\n\n"
 		for _, item := range dataMap[fullName]["children"] {
 			md += "## " + item["name"]
@@ -167,16 +326,18 @@ func (d ApiDoc) OfflineMarkdown(out string, force bool) (err error) {
 			}
 			md += "\n\n"
 			md = d.handleMd(md, item)
-			md += item["doc_md"] + "\n\n\n"
+			md += item["doc_md"] + "\n\n"
+			for _, k := range []string{"sample_md", "schema_md"} {
+				if item[k] != "" {
+					md += item[k] + "\n\n"
+				}
+			}
+			md += "\n"
 		}
 		md += "\n\n"
 	}
 
-	if err := os.WriteFile(dest, []byte(md), 0644); err != nil {
-		return err
-	}
-
-	return
+	return md
 }
 
 func (d ApiDoc) handleMd(md string, item KVMap) string {
@@ -234,33 +395,6 @@ func (d ApiDoc) readTemplate(rp string) error {
 	return nil
 }
 
-func (d ApiDoc) renderHtml() string {
-	htmlStr := templateMap["index"]
-	if d.Conf.Cdn {
-		cssTemplate := templateMap["css_template_cdn"]
-		jsTemplate := templateMap["js_template_cdn"]
-
-		if d.Conf.CdnCssTemplate != "" {
-			cssTemplate = d.Conf.CdnCssTemplate
-		}
-		if d.Conf.CdnJsTemplate != "" {
-			jsTemplate = d.Conf.CdnJsTemplate
-		}
-
-		return strings.Replace(
-			strings.Replace(
-				htmlStr, "<!-- ___CSS_TEMPLATE___ -->", cssTemplate, -1,
-			), "<!-- ___JS_TEMPLATE___ -->", jsTemplate, -1,
-		)
-	} else {
-		return strings.Replace(
-			strings.Replace(
-				htmlStr, "<!-- ___CSS_TEMPLATE___ -->", templateMap["css_template_local"], -1,
-			), "<!-- ___JS_TEMPLATE___ -->", templateMap["js_template_local"], -1,
-		)
-	}
-}
-
 func (d ApiDoc) getDocData() {
 	for _, r := range d.Ge.Routes() {
 		funcValue := reflect.ValueOf(r.HandlerFunc)
@@ -275,8 +409,10 @@ func (d ApiDoc) getDocData() {
 			continue
 		}
 		docMap[filePath] = make(KVMap)
+		docDeclMap[filePath] = make(map[string]docDecl)
 
-		node, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.ParseComments)
+		fset := token.NewFileSet()
+		node, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
 		if err != nil {
 			slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
 			continue
@@ -285,6 +421,9 @@ func (d ApiDoc) getDocData() {
 			if fn, ok := n.(*ast.FuncDecl); ok {
 				fnName := fn.Name.Name
 				docMap[filePath][fnName] = fn.Doc.Text()
+				if fn.Doc != nil {
+					docDeclMap[filePath][fnName] = docDecl{fset: fset, doc: fn.Doc}
+				}
 			}
 			return true
 		})
@@ -293,18 +432,25 @@ func (d ApiDoc) getDocData() {
 
 func (d ApiDoc) getApiData() DataMap {
 	dataMap := make(DataMap)
+	// Items by group and handler, the routes of a handler are merged
+	items := make(map[string]KVMap)
 	for _, r := range d.Ge.Routes() {
+		if d.isDocRoute(r.Path) {
+			continue
+		}
+
 		pkgName, funcName := d.splitHandler(r.Handler)
 
 		if slices.Contains(d.Conf.Exclude, pkgName) {
 			continue
 		}
 
-		if dataMap[pkgName] == nil {
-			dataMap[pkgName] = make(RouterMap)
+		group := d.routeGroup(r)
+		if dataMap[group] == nil {
+			dataMap[group] = make(RouterMap)
 		}
-		if _, ok := dataMap[pkgName]["children"]; !ok {
-			dataMap[pkgName]["children"] = []KVMap{}
+		if _, ok := dataMap[group]["children"]; !ok {
+			dataMap[group]["children"] = []KVMap{}
 		}
 
 		if !slices.Contains(d.Conf.MethodsList, r.Method) {
@@ -317,11 +463,11 @@ func (d ApiDoc) getApiData() DataMap {
 			"name":     funcName,
 			"url":      url,
 			"method":   r.Method,
-			"router":   pkgName,
+			"router":   group,
 			"api_type": "api",
 		}
 
-		d.addApiData(dataMap, apiData, r.HandlerFunc)
+		d.addApiData(dataMap, items, apiData, r)
 	}
 
 	for k := range dataMap {
@@ -335,11 +481,105 @@ func (d ApiDoc) getApiData() DataMap {
 	return dataMap
 }
 
+// addLiveData adds the recorded samples and inferred schemas of each API
+func (d ApiDoc) addLiveData(dataMap DataMap) DataMap {
+	if d.Conf.Samples == nil && d.Conf.Schemas == nil {
+		return dataMap
+	}
+
+	newDataMap := make(DataMap, len(dataMap))
+	for router := range dataMap {
+		children := make([]KVMap, 0, len(dataMap[router]["children"]))
+		for _, item := range dataMap[router]["children"] {
+			newItem := maps.Clone(item)
+			if sampleMd := d.getSampleMd(item); sampleMd != "" {
+				newItem["sample_md"] = sampleMd
+			}
+			if schemaMd := d.getSchemaMd(item); schemaMd != "" {
+				newItem["schema_md"] = schemaMd
+			}
+			children = append(children, newItem)
+		}
+		newDataMap[router] = RouterMap{"children": children}
+	}
+
+	return newDataMap
+}
+
+func (d ApiDoc) getSampleMd(item KVMap) string {
+	if d.Conf.Samples == nil || d.Conf.SampleLimit <= 0 {
+		return ""
+	}
+
+	samples := []Sample{}
+	for _, route := range apiRoutes(item) {
+		rs, err := d.Conf.Samples.Recent(route.Method, route.Path, d.Conf.SampleLimit)
+		if err != nil {
+			slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+			continue
+		}
+		samples = append(samples, rs...)
+	}
+	if len(samples) == 0 {
+		return ""
+	}
+	slices.SortFunc(samples, func(a, b Sample) int {
+		return b.Time.Compare(a.Time)
+	})
+
+	sampleMd := "### samples\n\n"
+	for _, s := range samples[:min(len(samples), d.Conf.SampleLimit)] {
+		sampleMd += s.markdown()
+	}
+
+	return strings.TrimSpace(sampleMd)
+}
+
+func (d ApiDoc) getSchemaMd(item KVMap) string {
+	if d.Conf.Schemas == nil {
+		return ""
+	}
+
+	schemaMd := ""
+	for _, route := range apiRoutes(item) {
+		schemaMd += d.Conf.Schemas.markdown(route.Method, route.Path)
+	}
+	if schemaMd == "" {
+		return ""
+	}
+
+	return strings.TrimSpace("### schema\n\n" + schemaMd)
+}
+
+type apiRoute struct {
+	Path   string
+	Method string
+}
+
+// apiRoutes splits the `url` field of an API, e.g. "/a\t[GET] /a\t[POST]"
+func apiRoutes(item KVMap) []apiRoute {
+	routes := []apiRoute{}
+	for _, url := range strings.Split(item["url"], " ") {
+		urlS := strings.Split(url, "\t")
+		if len(urlS) != 2 {
+			continue
+		}
+		routes = append(routes, apiRoute{
+			Path:   urlS[0],
+			Method: strings.Trim(urlS[1], "[]"),
+		})
+	}
+
+	return routes
+}
+
 func (d ApiDoc) splitHandler(handler string) (string, string) {
 	handlerS := strings.Split(filepath.Base(handler), ".")
 	pkgName := handlerS[0]
 	funcName := handlerS[len(handlerS)-1]
 
+	pkgMu.Lock()
+	defer pkgMu.Unlock()
 	if pkgMap[pkgName] == nil {
 		pkgMap[pkgName] = []string{}
 	}
@@ -357,37 +597,61 @@ func (d ApiDoc) splitHandler(handler string) (string, string) {
 	return pkgName, funcName
 }
 
-func (d ApiDoc) addApiData(dataMap DataMap, apiData KVMap, hFunc gin.HandlerFunc) {
+// addApiData adds the item of a route, or merges the route into the item of its handler,
+// a handler named as another one of the group is named with its package, e.g. `order.List`
+func (d ApiDoc) addApiData(dataMap DataMap, items map[string]KVMap, apiData KVMap, r gin.RouteInfo) {
 	router := apiData["router"]
 
-	resultList := []KVMap{}
-	for _, v := range dataMap[router]["children"] {
-		if v["name"] == apiData["name"] {
-			resultList = append(resultList, v)
-		}
-	}
-	if len(resultList) > 0 {
+	key := router + "\n" + r.Handler
+	if item, ok := items[key]; ok {
 		for _, v := range [][]string{{"url", apiData["url"]}, {"method", apiData["method"]}} {
 			sList := strings.Split(
-				strings.Join([]string{resultList[0][v[0]], v[1]}, " "), " ",
+				strings.Join([]string{item[v[0]], v[1]}, " "), " ",
 			)
 			slices.Sort(sList)
 			sList = slices.CompactFunc(sList, strings.EqualFold)
-			resultList[0][v[0]] = strings.Join(sList, " ")
+			item[v[0]] = strings.Join(sList, " ")
 		}
 		return
 	}
 
-	doc := d.getApiDoc(hFunc, apiData["name"])
+	doc := d.getApiDoc(r.HandlerFunc, apiData["name"])
 	apiData["name_extra"], apiData["doc"], apiData["doc_md"] = d.splitDoc(doc)
 
+	for _, v := range dataMap[router]["children"] {
+		if v["name"] == apiData["name"] {
+			pkgName, _ := d.splitHandler(r.Handler)
+			apiData["name"] = pkgName + "." + apiData["name"]
+			break
+		}
+	}
+
+	items[key] = apiData
 	dataMap[router]["children"] = append(dataMap[router]["children"], apiData)
 }
 
-func (d ApiDoc) getApiDoc(hFunc gin.HandlerFunc, hFuncName string) string {
+func handlerName(hFunc gin.HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(hFunc).Pointer()).Name()
+}
+
+func handlerFile(hFunc gin.HandlerFunc) string {
 	funcValue := reflect.ValueOf(hFunc)
 	filePath, _ := runtime.FuncForPC(funcValue.Pointer()).FileLine(0)
-	funcDoc := docMap[filePath][hFuncName]
+
+	return filePath
+}
+
+// getApiDoc returns the doc of a handler in the locale, the doc of `Locale` if it has none
+func (d ApiDoc) getApiDoc(hFunc gin.HandlerFunc, hFuncName string) string {
+	file := handlerFile(hFunc)
+	funcDoc, langDocs := splitLangDoc(docMap[file][hFuncName])
+	if d.Conf.lang != "" {
+		if doc := sidecarDoc(file, hFuncName, d.Conf.lang); doc != "" {
+			funcDoc = doc
+		} else if doc := langDocs[strings.ToLower(d.Conf.lang)]; doc != "" {
+			funcDoc = doc
+		}
+	}
 	funcDoc = strings.Replace(funcDoc, "\t", strings.Repeat(" ", 4), -1)
 
 	return funcDoc
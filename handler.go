@@ -0,0 +1,21 @@
+package gin_docs
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handler returns the docs as an `http.Handler` for servers not built on Gin,
+// e.g. `mux.Handle("/docs/api/", h)`, the docs are served at `UrlPrefix`
+// and document the routes of `Ge`. The handler matches the full request path,
+// so it is mounted at `UrlPrefix` or above without `http.StripPrefix`
+func (d ApiDoc) Handler() (http.Handler, error) {
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+	if err := d.OnlineHtmlOn(engine); err != nil {
+		return nil, err
+	}
+
+	return engine, nil
+}
@@ -0,0 +1,86 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	apiDoc, err := New(r, WithTitle("Todo API", ""))
+	assert.NoError(t, err)
+
+	h, err := apiDoc.Handler()
+	assert.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.Handle("/docs/api/", h)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/docs/api/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "<html")
+
+	w = get("/docs/api/data")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "AddData")
+
+	assert.Equal(t, http.StatusOK, get("/docs/api/static/icon/book.svg").Code)
+
+	w = get("/docs/api/export/markdown")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "attachment; filename=todo-api.md", w.Header().Get("Content-Disposition"))
+	assert.Contains(t, w.Body.String(), "AddData")
+
+	w = get("/docs/api/export/")
+	data := map[string][]string{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	assert.Contains(t, data["exporters"], "json")
+
+	assert.Equal(t, http.StatusNotFound, get("/docs/api/export/pdf").Code)
+
+	// The documented engine is unchanged
+	assert.Len(t, r.Routes(), 1)
+
+	_, err = ApiDoc{Ge: r, Conf: (&Config{UrlPrefix: "docs"}).Default()}.Handler()
+	assert.NoError(t, err)
+}
+
+func TestHandlerServeMux(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	apiDoc, err := New(r, WithPrefix("/api/docs"))
+	assert.NoError(t, err)
+	h, err := apiDoc.Handler()
+	assert.NoError(t, err)
+
+	get := func(mux *http.ServeMux, url string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		mux.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Mounted at the root, the docs are served at `UrlPrefix`
+	mux := http.NewServeMux()
+	mux.Handle("/", h)
+	assert.Equal(t, http.StatusOK, get(mux, "/api/docs/"))
+	assert.Equal(t, http.StatusOK, get(mux, "/api/docs/data"))
+	assert.Equal(t, http.StatusNotFound, get(mux, "/docs/"))
+
+	// A stripped prefix no longer matches `UrlPrefix`
+	mux = http.NewServeMux()
+	mux.Handle("/api/docs/", http.StripPrefix("/api/docs", h))
+	assert.Equal(t, http.StatusNotFound, get(mux, "/api/docs/"))
+}
@@ -0,0 +1,253 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HTTP Archive 1.2, http://www.softwareishard.com/blog/har-12-spec/
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	Url         string         `json:"url"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HttpVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// harDocTime is the time of the documented requests, so the archives of the same docs are the same
+var harDocTime = time.Unix(0, 0).UTC()
+
+// harHeaders returns the headers sorted by name
+func harHeaders(headers map[string]string) []harNameValue {
+	nvs := []harNameValue{}
+	for name, value := range headers {
+		nvs = append(nvs, harNameValue{Name: name, Value: value})
+	}
+	sort.Slice(nvs, func(i, j int) bool {
+		return nvs[i].Name < nvs[j].Name
+	})
+
+	return nvs
+}
+
+// harQueryString returns the query params of a url in their order
+func harQueryString(rawUrl string) []harNameValue {
+	nvs := []harNameValue{}
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nvs
+	}
+	for _, pair := range strings.Split(u.RawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(pair, "=")
+		name, _ = url.QueryUnescape(name)
+		value, _ = url.QueryUnescape(value)
+		nvs = append(nvs, harNameValue{Name: name, Value: value})
+	}
+
+	return nvs
+}
+
+func newHarEntry(method, rawUrl string, started time.Time, duration time.Duration,
+	reqHeaders map[string]string, reqBody string,
+	status int, respHeaders map[string]string, respBody string,
+) harEntry {
+	ms := float64(duration.Microseconds()) / 1000
+	entry := harEntry{
+		StartedDateTime: started.Format(time.RFC3339Nano),
+		Time:            ms,
+		Request: harRequest{
+			Method:      method,
+			Url:         rawUrl,
+			HttpVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(reqHeaders),
+			QueryString: harQueryString(rawUrl),
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Status:      status,
+			StatusText:  http.StatusText(status),
+			HttpVersion: "HTTP/1.1",
+			Cookies:     []harNameValue{},
+			Headers:     harHeaders(respHeaders),
+			Content: harContent{
+				Size:     len(respBody),
+				MimeType: "application/json",
+				Text:     respBody,
+			},
+			HeadersSize: -1,
+			BodySize:    len(respBody),
+		},
+		Timings: harTimings{Wait: ms},
+	}
+	if reqBody != "" {
+		mimeType := "application/json"
+		if ct := reqHeaders["Content-Type"]; ct != "" {
+			mimeType = ct
+		}
+		entry.Request.PostData = &harPostData{MimeType: mimeType, Text: reqBody}
+	}
+	if ct := respHeaders["Content-Type"]; ct != "" {
+		entry.Response.Content.MimeType = ct
+	}
+
+	return entry
+}
+
+// harEntries returns an entry for each documented route with its request example,
+// then the recorded samples from the oldest
+func (d ApiDoc) harEntries() []harEntry {
+	groups, requests, _ := d.collectionRequests(":%s")
+
+	entries := []harEntry{}
+	samples := []Sample{}
+	for _, group := range groups {
+		for _, r := range requests[group] {
+			reqHeaders := map[string]string{}
+			if r.Body != "" {
+				reqHeaders["Content-Type"] = "application/json"
+			}
+			entry := newHarEntry(r.Method, d.baseUrl()+r.Path, harDocTime, 0,
+				reqHeaders, r.Body, 0, map[string]string{}, r.Response)
+			entry.Comment = "doc example of " + r.Name
+			if r.Summary != "" {
+				entry.Comment += ": " + r.Summary
+			}
+			entries = append(entries, entry)
+
+			if d.Conf.Samples == nil || d.Conf.SampleLimit <= 0 {
+				continue
+			}
+			rs, err := d.Conf.Samples.Recent(r.Method, r.Route, d.Conf.SampleLimit)
+			if err != nil {
+				slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+				continue
+			}
+			samples = append(samples, rs...)
+		}
+	}
+
+	slices.SortStableFunc(samples, func(a, b Sample) int {
+		return a.Time.Compare(b.Time)
+	})
+	for _, s := range samples {
+		entry := newHarEntry(s.Method, d.baseUrl()+s.Url, s.Time.UTC(), s.Duration,
+			s.RequestHeaders, s.RequestBody, s.Status, s.ResponseHeaders, s.ResponseBody)
+		entry.Comment = "sample"
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// harExport writes the documented requests and the recorded samples as a HAR 1.2 file,
+// the page adds the exchanges of its debugger
+func (d ApiDoc) harExport(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(map[string]harLog{"log": {
+		Version: "1.2",
+		Creator: harCreator{Name: PROJECT_NAME, Version: PROJECT_VERSION},
+		Entries: d.harEntries(),
+	}})
+}
+
+func (d ApiDoc) OfflineHar(out string, force bool) (err error) {
+	if out == "" {
+		out = "docs.har"
+	}
+
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	dest := filepath.Join(".", out)
+	if ok, _ := pathExists(dest); ok {
+		if !force {
+			return fmt.Errorf("target `%s` exists, set `force=true` to override.", dest)
+		}
+	}
+
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return d.harExport(f)
+}
@@ -0,0 +1,110 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHarExport(t *testing.T) {
+	apiDoc := setupCollection()
+	store := NewMemorySampleStore(0)
+	started := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	assert.NoError(t, store.Save(Sample{
+		Method: "GET", Path: "/todo/:id", Url: "/todo/1?full=true&lang=zh%2Dcn", Status: 200,
+		Time: started.Add(time.Second), Duration: 1500 * time.Microsecond,
+		RequestHeaders:  map[string]string{"Accept": "*/*"},
+		ResponseHeaders: map[string]string{"Content-Type": "application/json; charset=utf-8"},
+		ResponseBody:    `{"id": 1}`,
+	}))
+	assert.NoError(t, store.Save(Sample{
+		Method: "POST", Path: "/todo", Url: "/todo", Status: 201, Time: started,
+		RequestHeaders: map[string]string{"Content-Type": "application/json"},
+		RequestBody:    `{"name": "a"}`,
+	}))
+	apiDoc.Conf.Samples = store
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("har", buf))
+
+	har := map[string]harLog{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+	log := har["log"]
+	assert.Equal(t, "1.2", log.Version)
+	assert.Equal(t, PROJECT_NAME, log.Creator.Name)
+	assert.Len(t, log.Entries, 6)
+
+	// Documented requests
+	addTodo := log.Entries[1]
+	assert.Equal(t, "doc example of AddTodo: Add todo", addTodo.Comment)
+	assert.Equal(t, "POST", addTodo.Request.Method)
+	assert.Equal(t, "http://127.0.0.1:8080/todo", addTodo.Request.Url)
+	assert.Equal(t, "1970-01-01T00:00:00Z", addTodo.StartedDateTime)
+	assert.Contains(t, addTodo.Request.PostData.Text, `"name": "xx"`)
+	assert.Equal(t, `{"code": xxxx, "msg": "xxx", "data": null}`, addTodo.Response.Content.Text)
+	assert.Equal(t, "http://127.0.0.1:8080/todo/:id", log.Entries[2].Request.Url)
+
+	// Samples from the oldest
+	post, get := log.Entries[4], log.Entries[5]
+	assert.Equal(t, "sample", post.Comment)
+	assert.Equal(t, "http://127.0.0.1:8080/todo", post.Request.Url)
+	assert.Equal(t, 201, post.Response.Status)
+	assert.Equal(t, "Created", post.Response.StatusText)
+	assert.Equal(t, &harPostData{MimeType: "application/json", Text: `{"name": "a"}`}, post.Request.PostData)
+	assert.Equal(t, "http://127.0.0.1:8080/todo/1?full=true&lang=zh%2Dcn", get.Request.Url)
+	assert.Equal(t, "2024-05-01T08:00:01Z", get.StartedDateTime)
+	assert.Equal(t, 1.5, get.Time)
+	assert.Equal(t, []harNameValue{{Name: "full", Value: "true"}, {Name: "lang", Value: "zh-cn"}}, get.Request.QueryString)
+	assert.Equal(t, "application/json; charset=utf-8", get.Response.Content.MimeType)
+	assert.Nil(t, get.Request.PostData)
+
+	// The archive is stable
+	buf2 := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("har", buf2))
+	assert.Equal(t, buf.String(), buf2.String())
+}
+
+func TestHarDebugger(t *testing.T) {
+	// Without samples the archive has the documented requests, the page adds the exchanges of its debugger
+	apiDoc := setupCollection()
+	buf := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("har", buf))
+
+	har := map[string]harLog{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &har))
+	entries := har["log"].Entries
+	comments := []string{}
+	for _, e := range entries {
+		comments = append(comments, e.Comment)
+	}
+	assert.Equal(t, []string{
+		"doc example of GetData: Get data",
+		"doc example of AddTodo: Add todo",
+		"doc example of GetTodo GET: Get todo",
+		"doc example of GetTodo PUT: Get todo",
+	}, comments)
+	assert.Equal(t, "application/json", entries[1].Request.PostData.MimeType)
+	assert.Nil(t, entries[2].Request.PostData)
+	assert.Equal(t, "PUT", entries[3].Request.Method)
+
+	// The exchanges of the debugger are redacted as the samples
+	assert.NoError(t, apiDoc.init())
+	htmlStr, err := apiDoc.renderHtml()
+	assert.NoError(t, err)
+	assert.Contains(t, htmlStr, `const redactHeaders = ["Authorization","Cookie","Set-Cookie","Auth-Password-SHA2"]`)
+	assert.Regexp(t, `const maxBodySize = +4096`, htmlStr)
+}
+
+func TestOfflineHar(t *testing.T) {
+	apiDoc := setupCollection()
+	out := "docs_test.har"
+	defer os.Remove(out)
+	assert.NoError(t, apiDoc.OfflineHar(out, false))
+	assert.FileExists(t, out)
+	assert.Error(t, apiDoc.OfflineHar(out, false))
+	assert.NoError(t, apiDoc.OfflineHar(out, true))
+}
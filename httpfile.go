@@ -0,0 +1,88 @@
+package gin_docs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const hostVar = "host"
+
+// httpFile returns a `.http` file of the groups, for the HTTP clients of JetBrains IDEs
+// and the REST Client of VS Code, with a `@host` variable and the path params
+func (d ApiDoc) httpFile(groups []string, requests map[string][]collectionRequest, vars []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s = %s\n", hostVar, d.baseUrl())
+	for _, v := range vars {
+		fmt.Fprintf(&b, "@%s =\n", v)
+	}
+
+	for _, group := range groups {
+		for _, r := range requests[group] {
+			comment := r.Summary
+			if comment == "" {
+				comment = r.Name
+			}
+			fmt.Fprintf(&b, "\n### %s\n", comment)
+			fmt.Fprintf(&b, "%s {{%s}}%s\n", r.Method, hostVar, r.Path)
+			if r.Body != "" {
+				b.WriteString("Content-Type: application/json\n\n")
+				b.WriteString(r.Body + "\n")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// httpFileExport writes all the requests as one `.http` file
+func (d ApiDoc) httpFileExport(w io.Writer) error {
+	groups, requests, vars := d.collectionRequests("{{%s}}")
+	_, err := io.WriteString(w, d.httpFile(groups, requests, vars))
+
+	return err
+}
+
+// OfflineHttpFile writes the requests as `.http` files, one combined file
+// when `out` ends with `.http`, otherwise a directory of a file for each group
+func (d ApiDoc) OfflineHttpFile(out string, force bool) (err error) {
+	if out == "" {
+		out = "requests.http"
+	}
+
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	groups, requests, vars := d.collectionRequests("{{%s}}")
+
+	dest := filepath.Join(".", out)
+	if ok, _ := pathExists(dest); ok {
+		if !force {
+			return fmt.Errorf("target `%s` exists, set `force=true` to override.", dest)
+		}
+	}
+
+	if filepath.Ext(dest) == ".http" {
+		return os.WriteFile(dest, []byte(d.httpFile(groups, requests, vars)), 0644)
+	}
+
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.Mkdir(dest, os.ModePerm); err != nil {
+		return err
+	}
+	for _, group := range groups {
+		if err := os.WriteFile(
+			filepath.Join(dest, safeFileName(group)+".http"),
+			[]byte(d.httpFile([]string{group}, requests, vars)), 0644,
+		); err != nil {
+			return err
+		}
+	}
+
+	return
+}
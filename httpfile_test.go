@@ -0,0 +1,53 @@
+package gin_docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHttpFile(t *testing.T) {
+	apiDoc := setupCollection()
+	assert.NoError(t, apiDoc.init())
+
+	groups, requests, vars := apiDoc.collectionRequests("{{%s}}")
+	assert.Equal(t, "@host = http://127.0.0.1:8080\n"+
+		"@id =\n"+
+		"@path =\n"+
+		"\n### Get data\n"+
+		"GET {{host}}/files/{{path}}\n"+
+		"\n### Add todo\n"+
+		"POST {{host}}/todo\n"+
+		"Content-Type: application/json\n"+
+		"\n"+
+		`{"id": 1.5, "name": "xx", "tags": ["a", 1], "due": "tomorrow", "note": null, "Skip": "x"}`+"\n"+
+		"\n### Get todo\n"+
+		"GET {{host}}/todo/{{id}}\n"+
+		"\n### Get todo\n"+
+		"PUT {{host}}/todo/{{id}}\n",
+		apiDoc.httpFile(groups, requests, vars))
+}
+
+func TestOfflineHttpFile(t *testing.T) {
+	apiDoc := setupCollection()
+
+	out := "requests_test.http"
+	defer os.Remove(out)
+	assert.NoError(t, apiDoc.OfflineHttpFile(out, false))
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "POST {{host}}/todo\n")
+	assert.Error(t, apiDoc.OfflineHttpFile(out, false))
+
+	dir := "http_test"
+	defer os.RemoveAll(dir)
+	assert.NoError(t, apiDoc.OfflineHttpFile(dir, false))
+	data, err = os.ReadFile(filepath.Join(dir, "todo.http"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "### Add todo\n")
+	assert.NotContains(t, string(data), "/files/")
+	assert.FileExists(t, filepath.Join(dir, "files.http"))
+	assert.NoError(t, apiDoc.OfflineHttpFile(dir, true))
+}
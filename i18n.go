@@ -0,0 +1,224 @@
+package gin_docs
+
+import (
+	"cmp"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/kwkwc/gin-docs/internal/doclint"
+)
+
+// DocLocale is a locale of the docs besides `Config.Locale`, the doc of a handler in it is
+// the `@@@lang:xx` block of its comment or the `<Handler>.<lang>.md` file beside its source
+type DocLocale struct {
+	// Language tag, e.g. `zh`
+	Lang string `json:"lang" yaml:"lang" toml:"lang"`
+	// Title in the locale, default `Title`
+	Title string `json:"title" yaml:"title" toml:"title"`
+	// Description in the locale, default `Description`
+	Description string `json:"description" yaml:"description" toml:"description"`
+}
+
+var langTagRegexp = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{1,8})*$`)
+
+// langMarkRegexp matches the line starting the doc of a locale, e.g. `@@@lang:zh`
+var langMarkRegexp = doclint.LangMarkRegexp
+
+// splitLangDoc returns the doc before the first `@@@lang:xx` line and the docs of the locales,
+// a doc runs to the next `@@@lang:xx` line, the locales are lowercase
+func splitLangDoc(docSrc string) (string, map[string]string) {
+	marks := langMarkRegexp.FindAllStringSubmatchIndex(docSrc, -1)
+	if len(marks) == 0 {
+		return docSrc, nil
+	}
+
+	langDocs := map[string]string{}
+	for i, m := range marks {
+		end := len(docSrc)
+		if i+1 < len(marks) {
+			end = marks[i+1][0]
+		}
+		langDocs[strings.ToLower(docSrc[m[2]:m[3]])] = strings.Trim(docSrc[m[1]:end], "\n")
+	}
+
+	return docSrc[:marks[0][0]], langDocs
+}
+
+// sidecarDoc returns the doc of a handler in a locale from `<Handler>.<lang>.md`
+// beside its source file, "" if there is none
+func sidecarDoc(file, funcName, lang string) string {
+	b, err := os.ReadFile(filepath.Join(filepath.Dir(file), funcName+"."+lang+".md"))
+	if err != nil {
+		return ""
+	}
+
+	return strings.ReplaceAll(string(b), "\r\n", "\n")
+}
+
+// sidecarFiles returns the `<Handler>.<lang>.md` files beside the sources
+func (d ApiDoc) sidecarFiles(sources []string) []string {
+	files := []string{}
+	for _, dir := range uniqueDirs(sources) {
+		for _, l := range d.Conf.Locales {
+			matches, _ := filepath.Glob(filepath.Join(dir, "*."+l.Lang+".md"))
+			files = append(files, matches...)
+		}
+	}
+
+	return files
+}
+
+func uniqueDirs(files []string) []string {
+	dirs := []string{}
+	for _, file := range files {
+		if dir := filepath.Dir(file); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	return dirs
+}
+
+// Locale returns the ApiDoc with the docs, title and description in a locale of `Locales`,
+// e.g. `apiDoc.Locale("zh").OfflineHtml("htmldoc-zh", false)`, other locales are `Locale`.
+// Its config is a copy of `Conf` in the locale, the copies share the API data of `Conf`
+func (d ApiDoc) Locale(lang string) ApiDoc {
+	d.Conf = d.Conf.root()
+	if strings.EqualFold(lang, d.Conf.Locale) {
+		return d
+	}
+	for _, l := range d.Conf.Locales {
+		if strings.EqualFold(l.Lang, lang) {
+			c := *d.Conf
+			c.lang = l.Lang
+			c.base = d.Conf
+			d.Conf = &c
+			break
+		}
+	}
+
+	return d
+}
+
+// locale returns the locale of the docs
+func (d ApiDoc) locale() string {
+	switch {
+	case d.Conf.lang != "":
+		return d.Conf.lang
+	case d.Conf.Locale != "":
+		return d.Conf.Locale
+	}
+
+	return "en"
+}
+
+// locales returns the locales of the docs, the first is the default
+func (d ApiDoc) locales() []string {
+	langs := []string{ApiDoc{Conf: d.Conf.root()}.locale()}
+	for _, l := range d.Conf.Locales {
+		langs = append(langs, l.Lang)
+	}
+
+	return langs
+}
+
+// docLocale returns the locale of the docs in `Locales`, nil for `Locale`
+func (d ApiDoc) docLocale() *DocLocale {
+	if d.Conf.lang == "" {
+		return nil
+	}
+	for i := range d.Conf.Locales {
+		if d.Conf.Locales[i].Lang == d.Conf.lang {
+			return &d.Conf.Locales[i]
+		}
+	}
+
+	return nil
+}
+
+func (d ApiDoc) title() string {
+	if l := d.docLocale(); l != nil && l.Title != "" {
+		return l.Title
+	}
+
+	return d.Conf.Title
+}
+
+func (d ApiDoc) description() string {
+	if l := d.docLocale(); l != nil && l.Description != "" {
+		return l.Description
+	}
+
+	return d.Conf.Description
+}
+
+// requestLocale returns the ApiDoc in the locale of a request, by the `lang` query
+// or the `Accept-Language` header
+func (d ApiDoc) requestLocale(c *gin.Context) ApiDoc {
+	if len(d.Conf.Locales) == 0 {
+		return d
+	}
+	c.Writer.Header().Add("Vary", "Accept-Language")
+
+	if lang := c.Query("lang"); lang != "" {
+		return d.Locale(lang)
+	}
+
+	return d.Locale(acceptLanguage(c.GetHeader("Accept-Language"), d.locales()))
+}
+
+// acceptLanguage returns the locale preferred by an `Accept-Language` header, e.g.
+// `zh-CN,zh;q=0.9,en;q=0.8`, a locale matches a language of the same primary subtag,
+// "" if none matches
+func acceptLanguage(header string, langs []string) string {
+	type pref struct {
+		tag string
+		q   float64
+	}
+	prefs := []pref{}
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		if k, v, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(k) == "q" {
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				q = f
+			}
+		}
+		// Languages with `q=0` are refused
+		if q > 0 {
+			prefs = append(prefs, pref{tag: tag, q: q})
+		}
+	}
+	slices.SortStableFunc(prefs, func(a, b pref) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	primary := func(tag string) string {
+		p, _, _ := strings.Cut(tag, "-")
+		return p
+	}
+	for _, p := range prefs {
+		for _, lang := range langs {
+			if strings.EqualFold(lang, p.tag) {
+				return lang
+			}
+		}
+		for _, lang := range langs {
+			if strings.EqualFold(primary(lang), primary(p.tag)) {
+				return lang
+			}
+		}
+	}
+
+	return ""
+}
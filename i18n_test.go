@@ -0,0 +1,171 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+List todos
+
+@@@
+### args
+| args | required | location | type   | help      |
+|------|----------|----------|--------|-----------|
+| page | false    | query    | int    | page      |
+@@@
+
+@@@lang:zh
+列出待办
+
+@@@
+### 参数
+| 参数 | 必填  | 位置  | 类型 | 说明 |
+|------|-------|-------|------|------|
+| page | false | query | int  | 页码 |
+@@@
+*/
+func ListTodo(c *gin.Context) {
+	c.JSON(http.StatusOK, nil)
+}
+
+func setupLocale() ApiDoc {
+	r := gin.New()
+	r.GET("/todo", ListTodo)
+
+	c := (&Config{}).Default()
+	c.Title = "Todo API"
+	c.Locales = []DocLocale{{Lang: "zh", Title: "待办 API"}, {Lang: "ja"}}
+
+	return ApiDoc{Ge: r, Conf: c}
+}
+
+func TestSplitLangDoc(t *testing.T) {
+	doc, langDocs := splitLangDoc("Get todo\n\n@@@lang:zh\n获取待办\n\n  @@@lang:ZH-TW \n獲取待辦\n")
+	assert.Equal(t, "Get todo\n\n", doc)
+	assert.Equal(t, map[string]string{"zh": "获取待办", "zh-tw": "獲取待辦"}, langDocs)
+
+	doc, langDocs = splitLangDoc("Get todo\n\nSee @@@lang:zh\n")
+	assert.Equal(t, "Get todo\n\nSee @@@lang:zh\n", doc)
+	assert.Nil(t, langDocs)
+}
+
+func TestAcceptLanguage(t *testing.T) {
+	langs := []string{"en", "zh", "pt-BR"}
+	assert.Equal(t, "zh", acceptLanguage("zh-CN,zh;q=0.9,en;q=0.8", langs))
+	assert.Equal(t, "en", acceptLanguage("fr, en;q=0.5, zh;q=0.1", langs))
+	assert.Equal(t, "pt-BR", acceptLanguage("pt-br", langs))
+	assert.Equal(t, "pt-BR", acceptLanguage("pt", langs))
+	assert.Equal(t, "en", acceptLanguage("zh;q=0, en-US", langs))
+	assert.Equal(t, "", acceptLanguage("fr, *", langs))
+	assert.Equal(t, "", acceptLanguage("", langs))
+}
+
+func TestLocaleApiData(t *testing.T) {
+	apiDoc := setupLocale()
+	assert.NoError(t, apiDoc.init())
+
+	item := apiDoc.apiData()["gin-docs"]["children"][0]
+	assert.Equal(t, "List todos", item["name_extra"])
+	assert.Contains(t, item["doc_md"], "| page | false    | query    | int    | page      |")
+	assert.NotContains(t, item["doc_md"], "lang:zh")
+
+	zh := apiDoc.Locale("ZH")
+	assert.Equal(t, "zh", zh.locale())
+	assert.Equal(t, "待办 API", zh.title())
+	item = zh.apiData()["gin-docs"]["children"][0]
+	assert.Equal(t, "列出待办", item["name_extra"])
+	assert.Equal(t, "### 参数\n| 参数 | 必填  | 位置  | 类型 | 说明 |\n"+
+		"|------|-------|-------|------|------|\n| page | false | query | int  | 页码 |", item["doc_md"])
+	// The default locale is unchanged
+	assert.Equal(t, "List todos", apiDoc.apiData()["gin-docs"]["children"][0]["name_extra"])
+
+	// The docs of a locale without a doc are those of `Locale`
+	ja := apiDoc.Locale("ja")
+	assert.Equal(t, "Todo API", ja.title())
+	assert.Equal(t, "List todos", ja.apiData()["gin-docs"]["children"][0]["name_extra"])
+	assert.Equal(t, "", apiDoc.Locale("fr").Conf.lang)
+	assert.Equal(t, "", apiDoc.Locale("en").Conf.lang)
+	// The locales share the state of `Conf` and the default locale is reached from any of them
+	assert.Same(t, apiDoc.state(), zh.state())
+	assert.Same(t, apiDoc.Conf, zh.Locale("en").Conf)
+	assert.Equal(t, "ja", zh.Locale("ja").locale())
+	// The ApiDoc is still built from its fields without names
+	assert.Equal(t, "zh", ApiDoc{apiDoc.Ge, apiDoc.Conf}.Locale("zh").locale())
+	assert.Equal(t, []string{"en", "zh", "ja"}, apiDoc.locales())
+
+	// A `<Handler>.<lang>.md` file beside the source
+	assert.NoError(t, os.WriteFile("ListTodo.ja.md", []byte("Todo ichiran\r\n\r\n### hikisuu\r\n"), 0644))
+	defer os.RemoveAll("ListTodo.ja.md")
+	sources, _ := apiDoc.watchFiles()
+	assert.Contains(t, sources, filepath.Join(rootPath, "ListTodo.ja.md"))
+	apiDoc.Refresh()
+	item = ja.apiData()["gin-docs"]["children"][0]
+	assert.Equal(t, "Todo ichiran", item["name_extra"])
+	assert.Equal(t, "### hikisuu", item["doc_md"])
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, zh.Export("markdown", buf))
+	assert.Contains(t, buf.String(), "## ListTodo(列出待办)")
+}
+
+func TestLocaleData(t *testing.T) {
+	apiDoc := setupLocale()
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	get := func(url, acceptLanguage string) (*httptest.ResponseRecorder, map[string]any) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		apiDoc.Ge.ServeHTTP(w, req)
+		data := map[string]any{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+		return w, data
+	}
+
+	w, data := get("/docs/api/data", "zh-CN,zh;q=0.9,en;q=0.8")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Values("Vary"), "Accept-Language")
+	assert.Equal(t, "zh", data["lang"])
+	assert.Equal(t, []any{"en", "zh", "ja"}, data["langs"])
+	assert.Equal(t, "待办 API", data["title"])
+	item := data["data"].(map[string]any)["gin-docs"].(map[string]any)["children"].([]any)[0].(map[string]any)
+	assert.Equal(t, "列出待办", item["name_extra"])
+
+	// The `lang` query is chosen in the locale switcher
+	_, data = get("/docs/api/data?lang=en", "zh-CN")
+	assert.Equal(t, "en", data["lang"])
+	assert.Equal(t, "Todo API", data["title"])
+
+	_, data = get("/docs/api/data", "fr")
+	assert.Equal(t, "en", data["lang"])
+
+	_, data = get("/docs/api/openapi.json?lang=zh", "")
+	assert.Equal(t, "待办 API", data["info"].(map[string]any)["title"])
+}
+
+func TestLocaleValidate(t *testing.T) {
+	c := (&Config{}).Default()
+	c.Locale = "english"
+	c.Locales = []DocLocale{{Lang: "zh_CN"}, {Lang: "ja"}, {Lang: "JA"}, {}}
+	assert.EqualError(t, c.Validate(), "invalid config:\n"+
+		"`Locale` `english` is not a language tag\n"+
+		"`Locales[0].Lang` `zh_CN` is not a language tag\n"+
+		"`Locales[2].Lang` `JA` is duplicated\n"+
+		"`Locales[3].Lang` `` is not a language tag")
+}
+
+func TestLocaleLint(t *testing.T) {
+	fset, doc := parseLintSrc(t, "package p\n\n"+
+		"/*\nGet todo\n\n@@@\n### args\n@@@\n\n@@@lang:zh\n获取待办\n\n@@@\n### 参数\n@@@\n*/\n"+
+		"func GetTodo() {}\n")
+	assert.Empty(t, LintComment(fset, doc, nil, false))
+}
@@ -0,0 +1,359 @@
+// Package doclint lints the markdown of Gin-Docs handler doc comments, it only
+// depends on the standard library so the analyzer of `docslint` stays light
+package doclint
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Line is one line of a doc comment, Offset is the byte offset of Text in the file
+type Line struct {
+	Pos    token.Position
+	Offset int
+	Text   string
+}
+
+// CommentLines splits a comment group into lines without the comment markers
+func CommentLines(fset *token.FileSet, cg *ast.CommentGroup) []Line {
+	lines := []Line{}
+	if cg == nil {
+		return lines
+	}
+
+	for _, c := range cg.List {
+		pos := fset.Position(c.Slash)
+		text := c.Text[2:]
+		if strings.HasPrefix(c.Text, "/*") {
+			text = strings.TrimSuffix(text, "*/")
+		}
+
+		offset := pos.Offset + 2
+		for i, t := range strings.Split(text, "\n") {
+			linePos := pos
+			linePos.Line += i
+			linePos.Offset = offset
+			if i == 0 {
+				linePos.Column += 2
+			} else {
+				linePos.Column = 1
+			}
+			lines = append(lines, Line{Pos: linePos, Offset: offset, Text: t})
+			offset += len(t) + 1
+		}
+	}
+
+	return lines
+}
+
+type TextEdit struct {
+	// Byte offsets in the file
+	Start   int
+	End     int
+	NewText string
+}
+
+type Diagnostic struct {
+	Pos     token.Position
+	Rule    string
+	Message string
+	// Edits fixing the issue, empty if it can not be fixed automatically
+	Fixes []TextEdit
+}
+
+func (dg Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", dg.Pos, dg.Message, dg.Rule)
+}
+
+const (
+	RuleSummary     = "summary"
+	RuleUnclosed    = "unclosed-block"
+	RuleTable       = "table-columns"
+	RuleArgs        = "args-params"
+	RuleJson        = "invalid-json"
+	RuleHeadingSkip = "heading-skip"
+)
+
+var HeadingRegexp = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+// Sections of a document whose headings are aliases of each other
+var SectionAliases = map[string][]string{
+	"args":     {"args", "arguments", "params", "parameters"},
+	"request":  {"request", "requests"},
+	"response": {"response", "responses"},
+}
+
+// LangMarkRegexp matches the line starting the doc of a locale, e.g. `@@@lang:zh`
+var LangMarkRegexp = regexp.MustCompile(`(?m)^[ \t]*@@@lang:([A-Za-z0-9-]+)[ \t]*$`)
+
+var routeParamRegexp = regexp.MustCompile(`[:*]([^/]+)`)
+
+// routeParams returns the `:name` and `*name` segments of route paths
+func routeParams(paths []string) []string {
+	params := []string{}
+	for _, path := range paths {
+		for _, m := range routeParamRegexp.FindAllStringSubmatch(path, -1) {
+			if !slices.Contains(params, m[1]) {
+				params = append(params, m[1])
+			}
+		}
+	}
+	sort.Strings(params)
+
+	return params
+}
+
+// tableCells splits a markdown table row, escaped `\|` is kept in the cell
+func tableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+
+	cells := []string{}
+	cell := ""
+	for i := 0; i < len(row); i++ {
+		if row[i] == '\\' && i+1 < len(row) && row[i+1] == '|' {
+			cell += `\|`
+			i++
+			continue
+		}
+		if row[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell))
+			cell = ""
+			continue
+		}
+		cell += string(row[i])
+	}
+
+	return append(cells, strings.TrimSpace(cell))
+}
+
+func isTableRow(text string) bool {
+	return strings.HasPrefix(strings.TrimSpace(text), "|")
+}
+
+// LintComment lints the doc comment of a handler. paths are the routes of the handler,
+// set partial when they may lack group prefixes so that only missing params are reported.
+func LintComment(fset *token.FileSet, cg *ast.CommentGroup, paths []string, partial bool) []Diagnostic {
+	lines := CommentLines(fset, cg)
+	dgs := []Diagnostic{}
+	report := func(l Line, rule, format string, args ...any) *Diagnostic {
+		dgs = append(dgs, Diagnostic{Pos: l.Pos, Rule: rule, Message: fmt.Sprintf(format, args...)})
+		return &dgs[len(dgs)-1]
+	}
+
+	if cg == nil {
+		return dgs
+	}
+
+	// Summary line
+	for _, l := range lines {
+		text := strings.TrimSpace(l.Text)
+		if text == "" {
+			continue
+		}
+		if strings.HasPrefix(text, "@@@") || strings.HasPrefix(text, "#") ||
+			strings.HasPrefix(text, "|") || strings.HasPrefix(text, "```") {
+			report(l, RuleSummary, "missing summary line before `%s`", text)
+		}
+		break
+	}
+	if strings.TrimSpace(cg.Text()) == "" {
+		report(lines[0], RuleSummary, "missing summary line")
+	}
+
+	// @@@ blocks, the `@@@lang:xx` lines start the docs of the locales
+	marks := []Line{}
+	for _, l := range lines {
+		if strings.Contains(l.Text, "@@@") && !LangMarkRegexp.MatchString(l.Text) {
+			marks = append(marks, l)
+		}
+	}
+	if len(marks)%2 == 1 {
+		dg := report(marks[len(marks)-1], RuleUnclosed, "`@@@` block is not closed")
+		dg.Fixes = closeBlockFix(fset, cg, lines)
+	}
+
+	inFence := false
+	fenceLang := ""
+	fence := []Line{}
+	lastHeading := 0
+	var table []Line
+	for _, l := range lines {
+		text := strings.TrimSpace(l.Text)
+
+		// Tables
+		if !inFence && isTableRow(text) {
+			table = append(table, l)
+			continue
+		} else if len(table) > 0 {
+			lintTable(table, lines, paths, partial, report)
+			table = nil
+		}
+
+		// Fenced code blocks
+		if strings.HasPrefix(text, "```") {
+			if !inFence {
+				inFence = true
+				fenceLang = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(text, "```")))
+				fence = []Line{l}
+			} else {
+				inFence = false
+				if fenceLang == "json" {
+					lintJson(fence, report)
+				}
+			}
+			continue
+		}
+		if inFence {
+			fence = append(fence, l)
+			continue
+		}
+
+		// Headings
+		if m := HeadingRegexp.FindStringSubmatch(text); m != nil {
+			level := len(m[1])
+			if lastHeading > 0 && level > lastHeading+1 {
+				dg := report(l, RuleHeadingSkip,
+					"heading level skips from h%d to h%d", lastHeading, level)
+				start := l.Offset + strings.Index(l.Text, m[1])
+				dg.Fixes = []TextEdit{{
+					Start: start, End: start + level, NewText: strings.Repeat("#", lastHeading+1),
+				}}
+				level = lastHeading + 1
+			}
+			lastHeading = level
+		}
+	}
+	if len(table) > 0 {
+		lintTable(table, lines, paths, partial, report)
+	}
+
+	return dgs
+}
+
+func closeBlockFix(fset *token.FileSet, cg *ast.CommentGroup, lines []Line) []TextEdit {
+	last := cg.List[len(cg.List)-1]
+	end := fset.Position(last.End()).Offset
+	if strings.HasPrefix(last.Text, "//") {
+		return []TextEdit{{Start: end, End: end, NewText: "\n// @@@"}}
+	}
+
+	// Insert before `*/`, on its own line
+	lastLine := lines[len(lines)-1]
+	if strings.TrimSpace(lastLine.Text) == "" {
+		return []TextEdit{{Start: lastLine.Offset, End: lastLine.Offset, NewText: "@@@\n"}}
+	}
+	return []TextEdit{{Start: end - 2, End: end - 2, NewText: "\n@@@\n"}}
+}
+
+func lintJson(fence []Line, report func(Line, string, string, ...any) *Diagnostic) {
+	body := ""
+	for _, l := range fence[1:] {
+		body += l.Text + "\n"
+	}
+	if strings.TrimSpace(body) == "" {
+		return
+	}
+
+	var v any
+	err := json.Unmarshal([]byte(body), &v)
+	if err == nil {
+		return
+	}
+
+	l := fence[0]
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		n := strings.Count(body[:min(int(syntaxErr.Offset), len(body))], "\n")
+		l = fence[min(n+1, len(fence)-1)]
+	}
+	report(l, RuleJson, "invalid JSON in `json` block: %s", err)
+}
+
+func lintTable(
+	table, lines []Line, paths []string, partial bool,
+	report func(Line, string, string, ...any) *Diagnostic,
+) {
+	header := tableCells(table[0].Text)
+	for _, l := range table[1:] {
+		cells := tableCells(l.Text)
+		if len(cells) == len(header) {
+			continue
+		}
+
+		dg := report(l, RuleTable, "table row has %d columns, header has %d", len(cells), len(header))
+
+		// Pad missing cells, or drop extra empty ones
+		if len(cells) > len(header) && slices.ContainsFunc(cells[len(header):], func(c string) bool {
+			return c != ""
+		}) {
+			continue
+		}
+		if len(cells) < len(header) {
+			fill := " "
+			if strings.Trim(cells[0], "-: ") == "" {
+				fill = "---"
+			}
+			for len(cells) < len(header) {
+				cells = append(cells, fill)
+			}
+		}
+		cells = cells[:len(header)]
+		indent := l.Text[:len(l.Text)-len(strings.TrimLeft(l.Text, " \t"))]
+		dg.Fixes = []TextEdit{{
+			Start:   l.Offset,
+			End:     l.Offset + len(strings.TrimRight(l.Text, " \t")),
+			NewText: indent + "| " + strings.Join(cells, " | ") + " |",
+		}}
+	}
+
+	// The args table under an `args` heading
+	heading := ""
+	for _, l := range lines {
+		if l.Offset >= table[0].Offset {
+			break
+		}
+		if m := HeadingRegexp.FindStringSubmatch(strings.TrimSpace(l.Text)); m != nil {
+			heading = strings.ToLower(m[2])
+		}
+	}
+	if !slices.Contains(SectionAliases["args"], heading) || len(paths) == 0 {
+		return
+	}
+
+	locationIndex := slices.IndexFunc(header, func(c string) bool {
+		return strings.EqualFold(c, "location")
+	})
+	params := routeParams(paths)
+	listed := []string{}
+	for _, l := range table[1:] {
+		cells := tableCells(l.Text)
+		if strings.Trim(cells[0], "-: ") == "" {
+			continue
+		}
+		name := strings.Trim(cells[0], "`")
+		isPath := locationIndex >= 0 && locationIndex < len(cells) &&
+			strings.EqualFold(cells[locationIndex], "path")
+		if locationIndex < 0 || isPath {
+			listed = append(listed, name)
+		}
+		if isPath && !partial && !slices.Contains(params, name) {
+			report(l, RuleArgs, "path param `%s` is not in route `%s`", name, strings.Join(paths, "`, `"))
+		}
+	}
+	for _, p := range params {
+		if !slices.Contains(listed, p) {
+			report(table[0], RuleArgs, "route param `:%s` is missing from the args table", p)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package gin_docs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"os"
+	"slices"
+	"sort"
+
+	"github.com/kwkwc/gin-docs/internal/doclint"
+)
+
+type docLine = doclint.Line
+
+// commentLines splits a comment group into lines without the comment markers
+func commentLines(fset *token.FileSet, cg *ast.CommentGroup) []docLine {
+	return doclint.CommentLines(fset, cg)
+}
+
+type TextEdit = doclint.TextEdit
+
+type Diagnostic = doclint.Diagnostic
+
+const (
+	LintRuleSummary     = doclint.RuleSummary
+	LintRuleUnclosed    = doclint.RuleUnclosed
+	LintRuleTable       = doclint.RuleTable
+	LintRuleArgs        = doclint.RuleArgs
+	LintRuleJson        = doclint.RuleJson
+	LintRuleHeadingSkip = doclint.RuleHeadingSkip
+)
+
+// LintComment lints the doc comment of a handler. paths are the routes of the handler,
+// set partial when they may lack group prefixes so that only missing params are reported.
+func LintComment(fset *token.FileSet, cg *ast.CommentGroup, paths []string, partial bool) []Diagnostic {
+	return doclint.LintComment(fset, cg, paths, partial)
+}
+
+// Lint lints the doc comments of all documented handlers
+func (d ApiDoc) Lint() ([]Diagnostic, error) {
+	if err := d.init(); err != nil {
+		return nil, err
+	}
+
+	type handlerDoc struct {
+		filePath string
+		funcName string
+		paths    []string
+	}
+	handlers := []*handlerDoc{}
+	for _, r := range d.Ge.Routes() {
+		if d.isDocRoute(r.Path) || !slices.Contains(d.Conf.MethodsList, r.Method) {
+			continue
+		}
+		pkgName, funcName := d.splitHandler(r.Handler)
+		if slices.Contains(d.Conf.Exclude, pkgName) {
+			continue
+		}
+		filePath := handlerFile(r.HandlerFunc)
+		i := slices.IndexFunc(handlers, func(h *handlerDoc) bool {
+			return h.filePath == filePath && h.funcName == funcName
+		})
+		if i < 0 {
+			handlers = append(handlers, &handlerDoc{filePath: filePath, funcName: funcName})
+			i = len(handlers) - 1
+		}
+		if !slices.Contains(handlers[i].paths, r.Path) {
+			handlers[i].paths = append(handlers[i].paths, r.Path)
+		}
+	}
+
+	dgs := []Diagnostic{}
+	docMu.RLock()
+	defer docMu.RUnlock()
+	for _, h := range handlers {
+		decl := docDeclMap[h.filePath][h.funcName]
+		if decl.fset == nil {
+			continue
+		}
+		dgs = append(dgs, LintComment(decl.fset, decl.doc, h.paths, false)...)
+	}
+	sort.SliceStable(dgs, func(i, j int) bool {
+		if dgs[i].Pos.Filename != dgs[j].Pos.Filename {
+			return dgs[i].Pos.Filename < dgs[j].Pos.Filename
+		}
+		return dgs[i].Pos.Line < dgs[j].Pos.Line
+	})
+
+	return dgs, nil
+}
+
+// LintFix applies the automatic fixes to the source files and lints again
+func (d ApiDoc) LintFix() ([]Diagnostic, error) {
+	dgs, err := d.Lint()
+	if err != nil {
+		return nil, err
+	}
+
+	edits := map[string][]TextEdit{}
+	for _, dg := range dgs {
+		edits[dg.Pos.Filename] = append(edits[dg.Pos.Filename], dg.Fixes...)
+	}
+	for filePath, fileEdits := range edits {
+		if len(fileEdits) == 0 {
+			continue
+		}
+		if err := applyEdits(filePath, fileEdits); err != nil {
+			return nil, err
+		}
+		docMu.Lock()
+		delete(docMap, filePath)
+		delete(docDeclMap, filePath)
+		docMu.Unlock()
+	}
+
+	return d.Lint()
+}
+
+func applyEdits(filePath string, edits []TextEdit) error {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].Start > edits[j].Start })
+	for i, e := range edits {
+		if e.Start < 0 || e.End > len(src) || e.Start > e.End ||
+			(i > 0 && e.End > edits[i-1].Start) {
+			return fmt.Errorf("invalid edit of `%s` at offset %d", filePath, e.Start)
+		}
+		src = slices.Concat(src[:e.Start], []byte(e.NewText), src[e.End:])
+	}
+
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, src, info.Mode())
+}
@@ -0,0 +1,98 @@
+package gin_docs
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const lintSrc = "package p\n\n" +
+	"/*\n" +
+	"### args\n" +
+	"| args | required | location | type |\n" +
+	"|------|----------|----------|\n" +
+	"| id   | true     | path     | int  |\n" +
+	"| name | true     | path     | string |\n" +
+	"\n" +
+	"##### request\n" +
+	"```json\n" +
+	"{\"code\": xxxx}\n" +
+	"```\n" +
+	"@@@\n" +
+	"*/\n" +
+	"func GetTodo() {}\n"
+
+func parseLintSrc(t *testing.T, src string) (*token.FileSet, *ast.CommentGroup) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "todo.go", src, parser.ParseComments)
+	assert.NoError(t, err)
+
+	return fset, file.Decls[0].(*ast.FuncDecl).Doc
+}
+
+func TestLintComment(t *testing.T) {
+	fset, doc := parseLintSrc(t, lintSrc)
+	dgs := LintComment(fset, doc, []string{"/todo/:id/:tag"}, false)
+
+	got := []string{}
+	for _, dg := range dgs {
+		got = append(got, dg.String())
+	}
+	assert.ElementsMatch(t, []string{
+		"todo.go:4:1: missing summary line before `### args` (summary)",
+		"todo.go:14:1: `@@@` block is not closed (unclosed-block)",
+		"todo.go:6:1: table row has 3 columns, header has 4 (table-columns)",
+		"todo.go:8:1: path param `name` is not in route `/todo/:id/:tag` (args-params)",
+		"todo.go:5:1: route param `:tag` is missing from the args table (args-params)",
+		"todo.go:12:1: invalid JSON in `json` block: invalid character 'x' looking for beginning of value (invalid-json)",
+		"todo.go:10:1: heading level skips from h3 to h5 (heading-skip)",
+	}, got)
+
+	// Literal paths of the analyzer may lack group prefixes
+	dgs = LintComment(fset, doc, []string{"/:tag"}, true)
+	for _, dg := range dgs {
+		assert.NotContains(t, dg.Message, "`name`")
+	}
+}
+
+func TestLintFix(t *testing.T) {
+	fset, doc := parseLintSrc(t, lintSrc)
+	dgs := LintComment(fset, doc, nil, false)
+
+	edits := []TextEdit{}
+	for _, dg := range dgs {
+		edits = append(edits, dg.Fixes...)
+	}
+	filePath := filepath.Join(t.TempDir(), "todo.go")
+	assert.NoError(t, os.WriteFile(filePath, []byte(lintSrc), 0644))
+	assert.NoError(t, applyEdits(filePath, edits))
+
+	fixed, err := os.ReadFile(filePath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(fixed), "| ------ | ---------- | ---------- | --- |\n")
+	assert.Contains(t, string(fixed), "\n#### request\n")
+	assert.Contains(t, string(fixed), "@@@\n@@@\n*/")
+
+	fset, doc = parseLintSrc(t, string(fixed))
+	rules := []string{}
+	for _, dg := range LintComment(fset, doc, nil, false) {
+		rules = append(rules, dg.Rule)
+	}
+	assert.ElementsMatch(t, []string{LintRuleSummary, LintRuleJson}, rules)
+}
+
+func TestApiDocLint(t *testing.T) {
+	r := setupRouter()
+	apiDoc := ApiDoc{Ge: r, Conf: (&Config{}).Default()}
+
+	dgs, err := apiDoc.Lint()
+	assert.NoError(t, err)
+	assert.Len(t, dgs, 1)
+	assert.Equal(t, LintRuleSummary, dgs[0].Rule)
+	assert.Equal(t, "gin_docs_test.go", filepath.Base(dgs[0].Pos.Filename))
+}
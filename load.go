@@ -0,0 +1,297 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+const envPrefix = "GIN_DOCS_"
+
+// fileConfig is the part of `Config` set by a config file,
+// the fields not set keep their default
+type fileConfig struct {
+	Title          *string      `json:"title" yaml:"title" toml:"title"`
+	Version        *string      `json:"version" yaml:"version" toml:"version"`
+	Description    *string      `json:"description" yaml:"description" toml:"description"`
+	Locale         *string      `json:"locale" yaml:"locale" toml:"locale"`
+	Locales        []DocLocale  `json:"locales" yaml:"locales" toml:"locales"`
+	CdnCssTemplate *string      `json:"cdn_css_template" yaml:"cdn_css_template" toml:"cdn_css_template"`
+	CdnJsTemplate  *string      `json:"cdn_js_template" yaml:"cdn_js_template" toml:"cdn_js_template"`
+	Theme          *Theme       `json:"theme" yaml:"theme" toml:"theme"`
+	UrlPrefix      *string      `json:"url_prefix" yaml:"url_prefix" toml:"url_prefix"`
+	ApiHost        *string      `json:"api_host" yaml:"api_host" toml:"api_host"`
+	SiteUrl        *string      `json:"site_url" yaml:"site_url" toml:"site_url"`
+	NoDocText      *string      `json:"no_doc_text" yaml:"no_doc_text" toml:"no_doc_text"`
+	Enable         *bool        `json:"enable" yaml:"enable" toml:"enable"`
+	Cdn            *bool        `json:"cdn" yaml:"cdn" toml:"cdn"`
+	Ui             *string      `json:"ui" yaml:"ui" toml:"ui"`
+	UiLocaleDir    *string      `json:"ui_locale_dir" yaml:"ui_locale_dir" toml:"ui_locale_dir"`
+	Exclude        []string     `json:"exclude" yaml:"exclude" toml:"exclude"`
+	MethodsList    []string     `json:"methods_list" yaml:"methods_list" toml:"methods_list"`
+	PasswordSha2   *string      `json:"password_sha2" yaml:"password_sha2" toml:"password_sha2"`
+	AllMd          *bool        `json:"all_md" yaml:"all_md" toml:"all_md"`
+	SampleLimit    *int         `json:"sample_limit" yaml:"sample_limit" toml:"sample_limit"`
+	Versions       []DocVersion `json:"versions" yaml:"versions" toml:"versions"`
+	Services       []DocService `json:"services" yaml:"services" toml:"services"`
+}
+
+// LoadConfig reads a YAML, TOML or JSON config file by its extension, then applies
+// the `GIN_DOCS_*` environment variables, the result is validated
+func LoadConfig(path string) (*Config, error) {
+	c := (&Config{}).Default()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fc := fileConfig{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&fc)
+		// An empty file
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&fc)
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&fc)
+	default:
+		return nil, fmt.Errorf("unsupported config format `%s`, use `.yaml`, `.toml` or `.json`", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file `%s`: %s", path, err)
+	}
+	fc.apply(c)
+
+	if err := c.LoadEnv(); err != nil {
+		return nil, err
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// apply sets the fields of the config which are set in the file
+func (fc fileConfig) apply(c *Config) {
+	src, dest := reflect.ValueOf(fc), reflect.ValueOf(c).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		f := src.Field(i)
+		if f.IsNil() {
+			continue
+		}
+		if f.Kind() == reflect.Pointer {
+			f = f.Elem()
+		}
+		dest.FieldByName(src.Type().Field(i).Name).Set(f)
+	}
+}
+
+// LoadEnv overrides the config by the `GIN_DOCS_*` environment variables, e.g.
+// `GIN_DOCS_TITLE`, `GIN_DOCS_ENABLE=false` or `GIN_DOCS_METHODS_LIST=GET,POST`
+func (c *Config) LoadEnv() error {
+	errs := []error{}
+	t, dest := reflect.TypeOf(fileConfig{}), reflect.ValueOf(c).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		name := envPrefix + strings.ToUpper(t.Field(i).Tag.Get("json"))
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		f := dest.FieldByName(t.Field(i).Name)
+		switch f.Kind() {
+		case reflect.String:
+			f.SetString(value)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("`%s` is not a boolean: `%s`", name, value))
+				continue
+			}
+			f.SetBool(b)
+		case reflect.Int:
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("`%s` is not an integer: `%s`", name, value))
+				continue
+			}
+			f.SetInt(int64(n))
+		case reflect.Slice:
+			if f.Type().Elem().Kind() != reflect.String {
+				errs = append(errs, fmt.Errorf("`%s` can only be set in a config file", name))
+				continue
+			}
+			items := []string{}
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			f.Set(reflect.ValueOf(items))
+		default:
+			errs = append(errs, fmt.Errorf("`%s` can only be set in a config file", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+var httpMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodConnect, http.MethodOptions, http.MethodTrace,
+}
+
+func isHttpUrl(s string) bool {
+	u, err := url.Parse(s)
+
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// Validate checks the config, all the problems are returned together
+func (c *Config) Validate() error {
+	errs := []error{}
+	addErr := func(format string, a ...any) {
+		errs = append(errs, fmt.Errorf(format, a...))
+	}
+
+	switch {
+	case c.UrlPrefix == "":
+		addErr("`UrlPrefix` is empty, the docs would be mounted at the root")
+	case !strings.HasPrefix(c.UrlPrefix, "/"):
+		addErr("`UrlPrefix` `%s` must start with `/`", c.UrlPrefix)
+	case strings.HasSuffix(c.UrlPrefix, "/"):
+		addErr("`UrlPrefix` `%s` must not end with `/`", c.UrlPrefix)
+	}
+
+	if c.ApiHost != "" && !isHttpUrl(c.ApiHost) {
+		addErr("`ApiHost` `%s` is not an http(s) url", c.ApiHost)
+	}
+	if c.SiteUrl != "" && !isHttpUrl(c.SiteUrl) {
+		addErr("`SiteUrl` `%s` is not an http(s) url", c.SiteUrl)
+	}
+	if c.Locale != "" && !langTagRegexp.MatchString(c.Locale) {
+		addErr("`Locale` `%s` is not a language tag", c.Locale)
+	}
+	langs := []string{strings.ToLower(ApiDoc{Conf: c}.locale())}
+	for i, l := range c.Locales {
+		switch {
+		case !langTagRegexp.MatchString(l.Lang):
+			addErr("`Locales[%d].Lang` `%s` is not a language tag", i, l.Lang)
+		case slices.Contains(langs, strings.ToLower(l.Lang)):
+			addErr("`Locales[%d].Lang` `%s` is duplicated", i, l.Lang)
+		}
+		langs = append(langs, strings.ToLower(l.Lang))
+	}
+	errs = append(errs, c.Theme.validate()...)
+
+	switch {
+	case !validUi(c.Ui):
+		addErr("`Ui` `%s` must be one of %s", c.Ui, strings.Join(uis, ", "))
+	case c.Ui != "" && c.Ui != UiVue && c.PasswordSha2 != "":
+		addErr("`Ui` `%s` can not send the password of `PasswordSha2`, use `%s`", c.Ui, UiVue)
+	}
+	if err := checkUiAssets(c.Ui, c.Cdn); err != nil {
+		errs = append(errs, err)
+	}
+	if c.UiLocaleDir != "" {
+		if info, err := os.Stat(c.UiLocaleDir); err != nil || !info.IsDir() {
+			addErr("`UiLocaleDir` `%s` is not a directory", c.UiLocaleDir)
+		}
+	}
+
+	if len(c.MethodsList) == 0 {
+		addErr("`MethodsList` is empty, no routes would be shown")
+	}
+	for _, m := range c.MethodsList {
+		if !slices.Contains(httpMethods, m) {
+			addErr("`MethodsList` has unknown method `%s`, use one of %s", m, strings.Join(httpMethods, ", "))
+		}
+	}
+
+	if c.PasswordSha2 != "" {
+		if _, err := hex.DecodeString(c.PasswordSha2); err != nil || len(c.PasswordSha2) != 64 {
+			addErr("`PasswordSha2` must be a SHA256 hash of 64 hex characters, got %d characters", len(c.PasswordSha2))
+		} else if strings.ToLower(c.PasswordSha2) != c.PasswordSha2 {
+			// The page sends the hash in lowercase
+			addErr("`PasswordSha2` must be in lowercase hex")
+		}
+	}
+
+	if c.SampleLimit < 0 {
+		addErr("`SampleLimit` must not be negative, got %d", c.SampleLimit)
+	}
+
+	versionNames := map[string]bool{}
+	for i, v := range c.Versions {
+		switch {
+		case v.Name == "":
+			addErr("`Versions[%d].Name` is empty", i)
+		case versionNames[v.Name]:
+			addErr("`Versions[%d].Name` `%s` is duplicated", i, v.Name)
+		}
+		versionNames[v.Name] = true
+		if v.PathPrefix != "" && !strings.HasPrefix(v.PathPrefix, "/") {
+			addErr("`Versions[%d].PathPrefix` `%s` must start with `/`", i, v.PathPrefix)
+		}
+		if v.DataFile != "" {
+			if _, err := os.Stat(v.DataFile); err != nil {
+				addErr("`Versions[%d].DataFile` `%s` can not be read: %s", i, v.DataFile, err)
+			}
+		}
+	}
+
+	serviceNames := map[string]bool{}
+	for i, s := range c.Services {
+		switch {
+		case s.Name == "":
+			addErr("`Services[%d].Name` is empty", i)
+		case serviceNames[s.Name]:
+			addErr("`Services[%d].Name` `%s` is duplicated", i, s.Name)
+		}
+		serviceNames[s.Name] = true
+		if s.Url != "" && s.DataFile != "" {
+			addErr("`Services[%d]` sets both `Url` and `DataFile`", i)
+		}
+		if s.Url != "" {
+			if !isHttpUrl(s.Url) {
+				addErr("`Services[%d].Url` `%s` is not an http(s) url", i, s.Url)
+			}
+		}
+		if s.DataFile != "" {
+			if _, err := os.Stat(s.DataFile); err != nil {
+				addErr("`Services[%d].DataFile` `%s` can not be read: %s", i, s.DataFile, err)
+			}
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid config:\n%w", err)
+	}
+
+	return nil
+}
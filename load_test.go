@@ -0,0 +1,162 @@
+package gin_docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	for name, content := range map[string]string{
+		"docs.yaml": `
+title: Todo API
+url_prefix: /docs
+methods_list: [GET, POST]
+all_md: false
+services:
+  - name: user
+    url: http://user:8080/docs/api
+    headers:
+      Auth-Password-SHA2: abc
+theme:
+  primary_color: "#e4393c"
+  dark_mode: auto
+`,
+		"docs.toml": `
+title = "Todo API"
+url_prefix = "/docs"
+methods_list = ["GET", "POST"]
+all_md = false
+
+[[services]]
+name = "user"
+url = "http://user:8080/docs/api"
+headers = { Auth-Password-SHA2 = "abc" }
+
+[theme]
+primary_color = "#e4393c"
+dark_mode = "auto"
+`,
+		"docs.json": `{
+	"title": "Todo API",
+	"url_prefix": "/docs",
+	"methods_list": ["GET", "POST"],
+	"all_md": false,
+	"services": [{"name": "user", "url": "http://user:8080/docs/api", "headers": {"Auth-Password-SHA2": "abc"}}],
+	"theme": {"primary_color": "#e4393c", "dark_mode": "auto"}
+}`,
+	} {
+		c, err := LoadConfig(writeConfig(t, name, content))
+		assert.NoError(t, err, name)
+		assert.Equal(t, "Todo API", c.Title, name)
+		assert.Equal(t, "/docs", c.UrlPrefix, name)
+		assert.Equal(t, []string{"GET", "POST"}, c.MethodsList, name)
+		assert.False(t, c.AllMd, name)
+		assert.Equal(t, []DocService{
+			{Name: "user", Url: "http://user:8080/docs/api", Headers: map[string]string{"Auth-Password-SHA2": "abc"}},
+		}, c.Services, name)
+		assert.Equal(t, Theme{PrimaryColor: "#e4393c", DarkMode: "auto"}, c.Theme, name)
+		// Not set in the file
+		assert.Equal(t, "1.0.0", c.Version, name)
+		assert.True(t, c.Enable, name)
+		assert.Equal(t, 3, c.SampleLimit, name)
+	}
+
+	c, err := LoadConfig(writeConfig(t, "empty.yml", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "API Doc", c.Title)
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, "docs.ini", "title = x"))
+	assert.EqualError(t, err, "unsupported config format `.ini`, use `.yaml`, `.toml` or `.json`")
+
+	_, err = LoadConfig(writeConfig(t, "docs.yaml", "titel: x"))
+	assert.ErrorContains(t, err, "field titel not found")
+
+	_, err = LoadConfig(writeConfig(t, "docs.json", `{"enable": "yes"}`))
+	assert.ErrorContains(t, err, "invalid config file")
+
+	_, err = LoadConfig(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+}
+
+func TestLoadEnv(t *testing.T) {
+	t.Setenv("GIN_DOCS_TITLE", "Staging API")
+	t.Setenv("GIN_DOCS_ENABLE", "false")
+	t.Setenv("GIN_DOCS_METHODS_LIST", "GET, DELETE,")
+	t.Setenv("GIN_DOCS_SAMPLE_LIMIT", "5")
+
+	c, err := LoadConfig(writeConfig(t, "docs.yaml", "title: Todo API\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Staging API", c.Title)
+	assert.False(t, c.Enable)
+	assert.Equal(t, []string{"GET", "DELETE"}, c.MethodsList)
+	assert.Equal(t, 5, c.SampleLimit)
+
+	t.Setenv("GIN_DOCS_CDN", "maybe")
+	t.Setenv("GIN_DOCS_SAMPLE_LIMIT", "x")
+	t.Setenv("GIN_DOCS_VERSIONS", "v1")
+	t.Setenv("GIN_DOCS_THEME", "dark")
+	err = (&Config{}).Default().LoadEnv()
+	assert.EqualError(t, err, "`GIN_DOCS_THEME` can only be set in a config file\n"+
+		"`GIN_DOCS_CDN` is not a boolean: `maybe`\n"+
+		"`GIN_DOCS_SAMPLE_LIMIT` is not an integer: `x`\n"+
+		"`GIN_DOCS_VERSIONS` can only be set in a config file")
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, (&Config{}).Default().Validate())
+
+	c := (&Config{}).Default()
+	c.UrlPrefix = ""
+	c.MethodsList = []string{"GET", "GTE"}
+	c.PasswordSha2 = "admin"
+	c.SampleLimit = -1
+	c.Versions = []DocVersion{{Name: "v1", PathPrefix: "v1"}, {Name: "v1"}}
+	c.Services = []DocService{{Name: "user", Url: "user:8080"}, {DataFile: "missing", Url: "http://order"}}
+	err := c.Validate()
+	assert.EqualError(t, err, "invalid config:\n"+
+		"`UrlPrefix` is empty, the docs would be mounted at the root\n"+
+		"`MethodsList` has unknown method `GTE`, use one of GET, HEAD, POST, PUT, PATCH, DELETE, CONNECT, OPTIONS, TRACE\n"+
+		"`PasswordSha2` must be a SHA256 hash of 64 hex characters, got 5 characters\n"+
+		"`SampleLimit` must not be negative, got -1\n"+
+		"`Versions[0].PathPrefix` `v1` must start with `/`\n"+
+		"`Versions[1].Name` `v1` is duplicated\n"+
+		"`Services[0].Url` `user:8080` is not an http(s) url\n"+
+		"`Services[1].Name` is empty\n"+
+		"`Services[1]` sets both `Url` and `DataFile`\n"+
+		"`Services[1].DataFile` `missing` can not be read: stat missing: no such file or directory")
+
+	c = (&Config{}).Default()
+	c.UrlPrefix = "/docs/"
+	c.MethodsList = nil
+	assert.EqualError(t, c.Validate(), "invalid config:\n"+
+		"`UrlPrefix` `/docs/` must not end with `/`\n"+
+		"`MethodsList` is empty, no routes would be shown")
+
+	c.ApiHost = "api.example.com"
+	assert.ErrorContains(t, c.Validate(), "`ApiHost` `api.example.com` is not an http(s) url")
+	c.SiteUrl = "docs.example.com"
+	assert.ErrorContains(t, c.Validate(), "`SiteUrl` `docs.example.com` is not an http(s) url")
+	// The page sends the hash in lowercase, an uppercase hash would lock everyone out
+	c.PasswordSha2 = "8C6976E5B5410415BDE908BD4DEE15DFB167A9C873FC4BB8A81F6F2AB448A918"
+	assert.ErrorContains(t, c.Validate(), "`PasswordSha2` must be in lowercase hex")
+
+	// The docs are mounted with a config not checked before, the mistakes are logged
+	r := gin.New()
+	c = (&Config{}).Default()
+	c.UrlPrefix = "/docs/"
+	assert.NoError(t, ApiDoc{Ge: r, Conf: c}.OnlineHtml())
+	assert.NotEmpty(t, r.Routes())
+}
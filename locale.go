@@ -0,0 +1,146 @@
+package gin_docs
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// baseLocale is the locale the messages of the page are written in,
+// the catalogs are checked against its bundled catalog
+const baseLocale = "en"
+
+// Message catalogs of the page bundled with the package, `<lang>.json`
+//
+//go:embed static/locale/*.json
+var localeFS embed.FS
+
+var (
+	localeMu  sync.RWMutex
+	localeMap = map[string]map[string]string{}
+)
+
+// RegisterLocale adds a message catalog of the page for all ApiDocs, the keys are
+// the English messages, the catalog of the same locale is replaced
+func RegisterLocale(lang string, messages map[string]string) {
+	localeMu.Lock()
+	defer localeMu.Unlock()
+
+	localeMap[strings.ToLower(lang)] = messages
+}
+
+// readLocales reads the `<lang>.json` catalogs of a directory
+func readLocales(fsys fs.FS, dir string) (map[string]map[string]string, error) {
+	files, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	locales := map[string]map[string]string{}
+	for _, file := range files {
+		b, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		messages := map[string]string{}
+		if err := json.Unmarshal(b, &messages); err != nil {
+			return nil, fmt.Errorf("invalid locale `%s`: %s", file, err)
+		}
+		locales[strings.ToLower(strings.TrimSuffix(path.Base(file), ".json"))] = messages
+	}
+
+	return locales, nil
+}
+
+// uiLocales returns the message catalogs of the page by locale, the bundled ones,
+// those of `RegisterLocale` and those of `UiLocaleDir` in order of precedence
+func (d ApiDoc) uiLocales() (map[string]map[string]string, error) {
+	locales, err := readLocales(localeFS, "static/locale")
+	if err != nil {
+		return nil, err
+	}
+
+	localeMu.RLock()
+	for lang, messages := range localeMap {
+		locales[lang] = messages
+	}
+	localeMu.RUnlock()
+
+	if d.Conf.UiLocaleDir != "" {
+		dirLocales, err := readLocales(os.DirFS(d.Conf.UiLocaleDir), ".")
+		if err != nil {
+			return nil, fmt.Errorf("`UiLocaleDir` `%s`: %s", d.Conf.UiLocaleDir, err)
+		}
+		for lang, messages := range dirLocales {
+			locales[lang] = messages
+		}
+	}
+
+	return locales, nil
+}
+
+// uiLocaleFiles returns the catalogs of `UiLocaleDir`
+func (d ApiDoc) uiLocaleFiles() []string {
+	if d.Conf.UiLocaleDir == "" {
+		return nil
+	}
+	files, _ := filepath.Glob(filepath.Join(d.Conf.UiLocaleDir, "*.json"))
+
+	return files
+}
+
+// diffMessages returns the keys of the base catalog missing from a catalog, and its keys not in the base
+func diffMessages(base, messages map[string]string) (missing, unknown []string) {
+	for k := range base {
+		if _, ok := messages[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	for k := range messages {
+		if _, ok := base[k]; !ok {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(unknown)
+
+	return missing, unknown
+}
+
+// checkLocales reads the message catalogs of the page, the messages missing from a catalog
+// are shown in English, they are logged with the unknown ones
+func (d ApiDoc) checkLocales() error {
+	locales, err := d.uiLocales()
+	if err != nil {
+		return err
+	}
+	bundled, err := readLocales(localeFS, "static/locale")
+	if err != nil {
+		return err
+	}
+
+	langs := []string{}
+	for lang := range locales {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	for _, lang := range langs {
+		missing, unknown := diffMessages(bundled[baseLocale], locales[lang])
+		if len(missing) > 0 {
+			slog.Warn(fmt.Sprintf("%s: locale `%s` misses the messages `%s`\n", PROJECT_NAME, lang, strings.Join(missing, "`, `")))
+		}
+		if len(unknown) > 0 {
+			slog.Warn(fmt.Sprintf("%s: locale `%s` has unknown messages `%s`\n", PROJECT_NAME, lang, strings.Join(unknown, "`, `")))
+		}
+	}
+
+	return nil
+}
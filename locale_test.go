@@ -0,0 +1,89 @@
+package gin_docs
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBundledLocales(t *testing.T) {
+	apiDoc := setupTheme(Theme{})
+	assert.NoError(t, apiDoc.init())
+
+	locales, err := readLocales(localeFS, "static/locale")
+	assert.NoError(t, err)
+	langs := []string{}
+	for lang := range locales {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	assert.Equal(t, []string{"de", "en", "es", "fr", "ja", "ko", "zh"}, langs)
+
+	// The English catalog has the messages of the page
+	keys := map[string]string{}
+	for _, m := range regexp.MustCompile(`\$t\(["']([^"']+)["']\)`).FindAllStringSubmatch(templateMap["index"], -1) {
+		keys[m[1]] = m[1]
+	}
+	assert.Equal(t, keys, locales[baseLocale])
+
+	for _, lang := range langs {
+		missing, unknown := diffMessages(locales[baseLocale], locales[lang])
+		assert.Empty(t, missing, lang)
+		assert.Empty(t, unknown, lang)
+	}
+}
+
+func TestDiffMessages(t *testing.T) {
+	missing, unknown := diffMessages(
+		map[string]string{"Send": "Send", "Error": "Error", "Copied": "Copied"},
+		map[string]string{"Send": "Senden", "Eror": "Fehler"},
+	)
+	assert.Equal(t, []string{"Copied", "Error"}, missing)
+	assert.Equal(t, []string{"Eror"}, unknown)
+}
+
+func TestUiLocales(t *testing.T) {
+	RegisterLocale("pt-BR", map[string]string{"Send": "Enviar"})
+	defer func() {
+		localeMu.Lock()
+		delete(localeMap, "pt-br")
+		localeMu.Unlock()
+	}()
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "it.json"), []byte(`{"Send": "Invia"}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "FR.json"), []byte(`{"Send": "Expédier"}`), 0644))
+
+	apiDoc := setupTheme(Theme{})
+	apiDoc.Conf.UiLocaleDir = dir
+	assert.NoError(t, apiDoc.init())
+	locales, err := apiDoc.uiLocales()
+	assert.NoError(t, err)
+	assert.Equal(t, "Enviar", locales["pt-br"]["Send"])
+	assert.Equal(t, "Invia", locales["it"]["Send"])
+	// The catalogs of the directory replace the bundled ones
+	assert.Equal(t, map[string]string{"Send": "Expédier"}, locales["fr"])
+	assert.Equal(t, "送信", locales["ja"]["Send"])
+	assert.Equal(t, []string{filepath.Join(dir, "FR.json"), filepath.Join(dir, "it.json")}, apiDoc.uiLocaleFiles())
+
+	htmlStr, err := apiDoc.renderHtml()
+	assert.NoError(t, err)
+	assert.Contains(t, htmlStr, `"it":{"Send":"Invia"}`)
+	assert.Contains(t, htmlStr, `"ko":{`)
+	assert.NotContains(t, htmlStr, "zhLocale")
+
+	// The docs are not mounted with an invalid catalog
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "es.json"), []byte(`{"Send": 1}`), 0644))
+	assert.ErrorContains(t, apiDoc.OnlineHtml(), "`UiLocaleDir` `"+dir+"`: invalid locale `es.json`: ")
+	assert.Empty(t, apiDoc.Ge.Routes())
+
+	c := (&Config{}).Default()
+	c.UiLocaleDir = "missing"
+	assert.EqualError(t, c.Validate(), "invalid config:\n`UiLocaleDir` `missing` is not a directory")
+	assert.NoError(t, ApiDoc{Ge: gin.New(), Conf: (&Config{}).Default()}.checkLocales())
+}
@@ -0,0 +1,161 @@
+package gin_docs
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+	"golang.org/x/text/width"
+)
+
+// markdownParser parses the markdown of the docs for the AsciiDoc and reST exporters
+var markdownParser = goldmark.New(
+	goldmark.WithExtensions(extension.Table, extension.Strikethrough),
+).Parser()
+
+func parseMarkdown(md string) (ast.Node, []byte) {
+	src := []byte(md)
+
+	return markdownParser.Parse(text.NewReader(src)), src
+}
+
+// headingRanks returns the rank of each heading level of a doc,
+// so the sections of the target markup do not skip a level
+func headingRanks(doc ast.Node) map[int]int {
+	levels := []int{}
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if h, ok := n.(*ast.Heading); ok && entering && !slices.Contains(levels, h.Level) {
+			levels = append(levels, h.Level)
+		}
+		return ast.WalkContinue, nil
+	})
+	sort.Ints(levels)
+
+	ranks := map[int]int{}
+	for i, l := range levels {
+		ranks[l] = i
+	}
+
+	return ranks
+}
+
+// blockLines returns the source lines of a block, e.g. of a code block
+func blockLines(n ast.Node, src []byte) string {
+	var b strings.Builder
+	lines := n.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		seg := lines.At(i)
+		b.Write(seg.Value(src))
+	}
+
+	return b.String()
+}
+
+// plainText returns the text of the inline children of a node without markup
+func plainText(n ast.Node, src []byte) string {
+	var b strings.Builder
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			b.Write(util.UnescapePunctuations(c.Segment.Value(src)))
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				b.WriteString(" ")
+			}
+		case *ast.String:
+			b.Write(c.Value)
+		default:
+			b.WriteString(plainText(c, src))
+		}
+	}
+
+	return b.String()
+}
+
+// codeLanguage returns the language of a fenced code block, e.g. `json`
+func codeLanguage(n ast.Node, src []byte) string {
+	if fc, ok := n.(*ast.FencedCodeBlock); ok {
+		return string(fc.Language(src))
+	}
+
+	return ""
+}
+
+// textWidth returns the display width of a text, the wide characters are 2 columns
+func textWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		switch width.LookupRune(r).Kind() {
+		case width.EastAsianWide, width.EastAsianFullwidth:
+			w += 2
+		default:
+			w++
+		}
+	}
+
+	return w
+}
+
+var anchorRegexp = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// anchorId returns the id of an anchor, e.g. `api-todo-addtodo`, the letters of
+// all scripts are kept, e.g. `api-用户-addtodo`
+func anchorId(parts ...string) string {
+	return "api-" + strings.Trim(anchorRegexp.ReplaceAllString(strings.ToLower(strings.Join(parts, "-")), "-"), "-")
+}
+
+// indentLines indents the lines of a text except the empty ones
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = indent + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// itemTitle returns the title of an API, e.g. `AddTodo(Add todo)` as `OfflineMarkdown`
+func itemTitle(item KVMap) string {
+	if item["name_extra"] != "" {
+		return item["name"] + "(" + item["name_extra"] + ")"
+	}
+
+	return item["name"]
+}
+
+// itemRoutes returns the methods and urls of an API, e.g. `POST /todo`
+func itemRoutes(item KVMap) []string {
+	routes := []string{}
+	for _, r := range apiRoutes(item) {
+		routes = append(routes, r.Method+" "+r.Path)
+	}
+
+	return routes
+}
+
+// itemPlainDoc returns the doc of an API which is not markdown
+func (d ApiDoc) itemPlainDoc(item KVMap) string {
+	if item["doc"] == "" || (item["doc"] == d.Conf.NoDocText && item["doc_md"] != "") {
+		return ""
+	}
+
+	return item["doc"]
+}
+
+// sortedGroups returns the groups of the data sorted by name
+func sortedGroups(dataMap DataMap) []string {
+	groups := make([]string, 0, len(dataMap))
+	for group := range dataMap {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	return groups
+}
@@ -1,7 +1,13 @@
 package gin_docs
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,3 +21,86 @@ func verifyPassword(passwordSha2 string) gin.HandlerFunc {
 		}
 	}
 }
+
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body  bytes.Buffer
+	limit int
+}
+
+func (w *bodyCaptureWriter) capture(b []byte) {
+	if remain := w.limit - w.body.Len(); remain > 0 {
+		w.body.Write(b[:min(len(b), remain)])
+	}
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	w.capture(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	w.capture([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+// captureExchange runs the remaining handlers of a sampled request and
+// passes the captured request and response to fn
+func captureExchange(c *gin.Context, sc *SampleConfig, fn func(Sample, []byte, []byte)) {
+	path := c.FullPath()
+	if path == "" || rand.Float64() >= sc.Rate {
+		c.Next()
+		return
+	}
+
+	// Read one byte beyond the cap to know whether the body was truncated
+	limit := 0
+	if sc.MaxBodySize > 0 {
+		limit = sc.MaxBodySize + 1
+	}
+	var reqBody []byte
+	if c.Request.Body != nil {
+		reqBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, int64(limit)))
+		c.Request.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(reqBody), c.Request.Body), c.Request.Body}
+	}
+
+	w := &bodyCaptureWriter{ResponseWriter: c.Writer, limit: limit}
+	c.Writer = w
+
+	start := time.Now()
+	c.Next()
+
+	fn(Sample{
+		Method:          c.Request.Method,
+		Path:            path,
+		Url:             c.Request.URL.RequestURI(),
+		Status:          w.Status(),
+		Time:            start,
+		Duration:        time.Since(start),
+		RequestHeaders:  sc.redactHeaders(c.Request.Header),
+		ResponseHeaders: sc.redactHeaders(w.Header()),
+	}, reqBody, w.body.Bytes())
+}
+
+// RecordSamples captures real requests and responses of each route into store,
+// the most recent ones are shown as examples in the documentation
+func RecordSamples(store SampleStore, sc *SampleConfig) gin.HandlerFunc {
+	if sc == nil {
+		sc = (&SampleConfig{}).Default()
+	}
+	patterns := sc.fieldPatterns()
+
+	return func(c *gin.Context) {
+		captureExchange(c, sc, func(s Sample, reqBody, respBody []byte) {
+			s.Url = sc.redactUrl(s.Url)
+			s.RequestBody = sc.redactBody(reqBody, patterns)
+			s.ResponseBody = sc.redactBody(respBody, patterns)
+			if err := store.Save(s); err != nil {
+				slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnlineHtmlOnGroup(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	admin := r.Group("/admin", func(c *gin.Context) {
+		if c.GetHeader("X-Admin") == "" {
+			c.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.OnlineHtmlOn(admin))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/admin/docs/api/", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/admin/docs/api/data", nil)
+	req.Header.Set("X-Admin", "1")
+	req.Header.Set("Referer", "http://127.0.0.1:8080/admin/docs/api/")
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := map[string]any{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	assert.Equal(t, "http://127.0.0.1:8080", data["host"])
+	// The routes of the docs are not documented
+	children := data["data"].(map[string]any)["gin-docs"].(map[string]any)["children"].([]any)
+	assert.Len(t, children, 1)
+}
+
+func TestOnlineHtmlOnEngine(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	admin := gin.New()
+	c := (&Config{}).Default()
+	c.ApiHost = "http://api.example.com"
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.OnlineHtmlOn(admin))
+
+	// Nothing is registered on the documented engine
+	assert.Len(t, r.Routes(), 1)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/data", nil)
+	admin.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := map[string]any{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	assert.Equal(t, "http://api.example.com", data["host"])
+	assert.Contains(t, data["data"], "gin-docs")
+
+	assert.EqualError(t, apiDoc.OnlineHtmlOn(nil), "`OnlineHtmlOn` router is nil")
+}
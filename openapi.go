@@ -0,0 +1,178 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OpenAPI 3.1, https://spec.openapis.org/oas/v3.1.0
+type openApiDoc struct {
+	Openapi string                                  `json:"openapi"`
+	Info    openApiInfo                             `json:"info"`
+	Servers []openApiServer                         `json:"servers,omitempty"`
+	Tags    []openApiTag                            `json:"tags"`
+	Paths   map[string]map[string]*openApiOperation `json:"paths"`
+}
+
+type openApiInfo struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+type openApiServer struct {
+	Url string `json:"url"`
+}
+
+type openApiTag struct {
+	Name string `json:"name"`
+}
+
+type openApiOperation struct {
+	Tags        []string                   `json:"tags"`
+	Summary     string                     `json:"summary,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	OperationId string                     `json:"operationId"`
+	Parameters  []openApiParameter         `json:"parameters,omitempty"`
+	RequestBody *openApiBody               `json:"requestBody,omitempty"`
+	Responses   map[string]openApiResponse `json:"responses"`
+}
+
+type openApiParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required"`
+	Schema      map[string]any `json:"schema"`
+}
+
+type openApiBody struct {
+	Content map[string]openApiMediaType `json:"content"`
+}
+
+type openApiResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openApiMediaType `json:"content,omitempty"`
+}
+
+type openApiMediaType struct {
+	Schema  map[string]any `json:"schema,omitempty"`
+	Example any            `json:"example,omitempty"`
+}
+
+// openApiExample returns a `json` example of a doc as a value, or the text if it is not valid JSON,
+// e.g. `{"code": xxxx}`
+func openApiExample(example string) any {
+	var v any
+	if err := json.Unmarshal([]byte(example), &v); err != nil {
+		return example
+	}
+
+	return v
+}
+
+// openApiSchema returns the schema inferred by `InferSchemas`, nil if none is observed
+func (d ApiDoc) openApiSchema(method, path, part string) map[string]any {
+	if d.Conf.Schemas == nil {
+		return nil
+	}
+	body, err := d.Conf.Schemas.JSONSchema(method, path, part)
+	if err != nil {
+		return nil
+	}
+	schema := map[string]any{}
+	if err := json.Unmarshal(body, &schema); err != nil {
+		return nil
+	}
+	delete(schema, "$schema")
+
+	return schema
+}
+
+// openApiContent returns the JSON content of an example and a schema, nil if there are neither
+func openApiContent(example string, schema map[string]any) map[string]openApiMediaType {
+	if example == "" && schema == nil {
+		return nil
+	}
+	mt := openApiMediaType{Schema: schema}
+	if example != "" {
+		mt.Example = openApiExample(example)
+	}
+
+	return map[string]openApiMediaType{"application/json": mt}
+}
+
+// openApi returns the OpenAPI document of the routes, each group is a tag, the `json` examples
+// of a doc and the inferred schemas are the request body and the response
+func (d ApiDoc) openApi() openApiDoc {
+	groups, requests, _ := d.collectionRequests("{%s}")
+
+	doc := openApiDoc{
+		Openapi: "3.1.0",
+		Info: openApiInfo{
+			Title:       d.title(),
+			Version:     d.Conf.Version,
+			Description: d.description(),
+		},
+		Tags:  []openApiTag{},
+		Paths: map[string]map[string]*openApiOperation{},
+	}
+	if d.Conf.ApiHost != "" {
+		doc.Servers = []openApiServer{{Url: d.Conf.ApiHost}}
+	}
+
+	usedIds := map[string]bool{}
+	for _, group := range groups {
+		doc.Tags = append(doc.Tags, openApiTag{Name: group})
+		for _, r := range requests[group] {
+			op := &openApiOperation{
+				Tags:        []string{group},
+				Summary:     r.Summary,
+				Description: strings.TrimSpace(strings.TrimPrefix(r.Doc, r.Summary)),
+				OperationId: uniqueSlug(strings.ReplaceAll(r.Name, " ", "_"), usedIds),
+				Responses:   map[string]openApiResponse{},
+			}
+			if op.Summary == "" {
+				op.Summary = r.Name
+			}
+			if op.Description == d.Conf.NoDocText {
+				op.Description = ""
+			}
+
+			_, vars := pathVars(r.Route, "%s")
+			for _, v := range vars {
+				p := openApiParameter{Name: v, In: "path", Required: true, Schema: map[string]any{"type": "string"}}
+				if strings.Contains(r.Route, "*"+v) {
+					p.Description = "The rest of the path"
+				}
+				op.Parameters = append(op.Parameters, p)
+			}
+
+			if content := openApiContent(r.Body, d.openApiSchema(r.Method, r.Route, "request")); content != nil {
+				op.RequestBody = &openApiBody{Content: content}
+			}
+			op.Responses["200"] = openApiResponse{
+				Description: http.StatusText(http.StatusOK),
+				Content:     openApiContent(r.Response, d.openApiSchema(r.Method, r.Route, "response")),
+			}
+
+			if doc.Paths[r.Path] == nil {
+				doc.Paths[r.Path] = map[string]*openApiOperation{}
+			}
+			doc.Paths[r.Path][strings.ToLower(r.Method)] = op
+		}
+	}
+
+	return doc
+}
+
+// openApiExport writes the OpenAPI document of the routes as JSON
+func (d ApiDoc) openApiExport(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+
+	return enc.Encode(d.openApi())
+}
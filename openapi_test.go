@@ -0,0 +1,65 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpenApiExport(t *testing.T) {
+	apiDoc := setupCollection()
+	store := NewSchemaStore()
+	store.Observe("PUT", "/todo/:id", map[string]any{"name": "a"}, map[string]any{"id": 1.0}, nil)
+	apiDoc.Conf.Schemas = store
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, apiDoc.Export("openapi", buf))
+	doc := openApiDoc{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "3.1.0", doc.Openapi)
+	assert.Equal(t, openApiInfo{Title: "Todo API", Version: "1.0.0"}, doc.Info)
+	assert.Equal(t, []openApiServer{{Url: "http://127.0.0.1:8080"}}, doc.Servers)
+	assert.Equal(t, []openApiTag{{Name: "files"}, {Name: "todo"}}, doc.Tags)
+
+	addTodo := doc.Paths["/todo"]["post"]
+	assert.Equal(t, []string{"todo"}, addTodo.Tags)
+	assert.Equal(t, "Add todo", addTodo.Summary)
+	assert.Equal(t, "AddTodo", addTodo.OperationId)
+	assert.Contains(t, addTodo.Description, "### request")
+	assert.Equal(t, "xx", addTodo.RequestBody.Content["application/json"].Example.(map[string]any)["name"])
+	// Not valid JSON
+	assert.Equal(t, `{"code": xxxx, "msg": "xxx", "data": null}`, addTodo.Responses["200"].Content["application/json"].Example)
+
+	getTodo, putTodo := doc.Paths["/todo/{id}"]["get"], doc.Paths["/todo/{id}"]["put"]
+	assert.Equal(t, "GetTodo_GET", getTodo.OperationId)
+	assert.Equal(t, "GetTodo_PUT", putTodo.OperationId)
+	assert.Empty(t, getTodo.Description)
+	assert.Equal(t, []openApiParameter{{Name: "id", In: "path", Required: true, Schema: map[string]any{"type": "string"}}}, getTodo.Parameters)
+	assert.Nil(t, getTodo.RequestBody)
+	assert.Equal(t, openApiResponse{Description: "OK"}, getTodo.Responses["200"])
+
+	// Inferred schemas
+	schema := putTodo.RequestBody.Content["application/json"].Schema
+	assert.Equal(t, "object", schema["type"])
+	assert.NotContains(t, schema, "$schema")
+	assert.Contains(t, putTodo.Responses["200"].Content["application/json"].Schema["properties"], "id")
+
+	assert.Equal(t, "The rest of the path", doc.Paths["/files/{path}"]["get"].Parameters[0].Description)
+}
+
+func TestOpenApiJson(t *testing.T) {
+	apiDoc := setupCollection()
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/openapi.json", nil)
+	apiDoc.Ge.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"openapi": "3.1.0"`)
+}
@@ -0,0 +1,147 @@
+package gin_docs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GroupBy returns the group of a route in the navigation
+type GroupBy func(r gin.RouteInfo) string
+
+// GroupByPackage groups the routes by the package of their handlers
+func GroupByPackage(r gin.RouteInfo) string {
+	pkgName, _ := ApiDoc{}.splitHandler(r.Handler)
+
+	return pkgName
+}
+
+// GroupByPath groups the routes by the first segments of their paths,
+// e.g. `/api/todo/:id` is in `api/todo` with depth 2, the parameters are skipped
+func GroupByPath(depth int) GroupBy {
+	return func(r gin.RouteInfo) string {
+		segments := []string{}
+		for _, s := range strings.Split(r.Path, "/") {
+			if s == "" || strings.HasPrefix(s, ":") || strings.HasPrefix(s, "*") {
+				continue
+			}
+			if len(segments) == depth {
+				break
+			}
+			segments = append(segments, s)
+		}
+		if len(segments) == 0 {
+			return "/"
+		}
+
+		return strings.Join(segments, "/")
+	}
+}
+
+func (d ApiDoc) routeGroup(r gin.RouteInfo) string {
+	if d.Conf.GroupBy != nil {
+		return d.Conf.GroupBy(r)
+	}
+
+	return GroupByPackage(r)
+}
+
+// Option configures the ApiDoc created by `New`
+type Option func(c *Config) error
+
+// New returns an ApiDoc of the engine, the options are applied over the defaults
+func New(engine *gin.Engine, opts ...Option) (ApiDoc, error) {
+	if engine == nil {
+		return ApiDoc{}, errors.New("engine is nil")
+	}
+
+	c := (&Config{}).Default()
+	errs := []error{}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return ApiDoc{}, err
+	}
+	if err := c.Validate(); err != nil {
+		return ApiDoc{}, err
+	}
+
+	return ApiDoc{Ge: engine, Conf: c}, nil
+}
+
+// WithTitle sets the title and the version shown in the page
+func WithTitle(title, version string) Option {
+	return func(c *Config) error {
+		if title == "" {
+			return errors.New("`WithTitle` title is empty")
+		}
+		c.Title = title
+		if version != "" {
+			c.Version = version
+		}
+		return nil
+	}
+}
+
+// WithPrefix sets the url prefix of the docs
+func WithPrefix(prefix string) Option {
+	return func(c *Config) error {
+		c.UrlPrefix = prefix
+		return nil
+	}
+}
+
+// WithAuth protects the docs by a password, it is stored as its SHA256 hash
+func WithAuth(password string) Option {
+	return func(c *Config) error {
+		if password == "" {
+			return errors.New("`WithAuth` password is empty")
+		}
+		sum := sha256.Sum256([]byte(password))
+		c.PasswordSha2 = hex.EncodeToString(sum[:])
+		return nil
+	}
+}
+
+// WithExporter adds an exporter by name
+func WithExporter(name string, e Exporter) Option {
+	return func(c *Config) error {
+		if name == "" || e.Export == nil {
+			return fmt.Errorf("`WithExporter` exporter `%s` needs a name and an export func", name)
+		}
+		if _, ok := c.Exporters[name]; ok {
+			return fmt.Errorf("`WithExporter` exporter `%s` is duplicated", name)
+		}
+		if c.Exporters == nil {
+			c.Exporters = make(map[string]Exporter)
+		}
+		c.Exporters[name] = e
+		return nil
+	}
+}
+
+// WithGrouping sets the group of the routes in the navigation, e.g. `GroupByPath(1)`
+func WithGrouping(groupBy GroupBy) Option {
+	return func(c *Config) error {
+		if groupBy == nil {
+			return errors.New("`WithGrouping` group func is nil")
+		}
+		c.GroupBy = groupBy
+		return nil
+	}
+}
+
+// WithConfig changes the other fields of the config
+func WithConfig(fn func(c *Config)) Option {
+	return func(c *Config) error {
+		fn(c)
+		return nil
+	}
+}
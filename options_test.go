@@ -0,0 +1,120 @@
+package gin_docs
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/kwkwc/gin-docs/testdata/api/order"
+	"github.com/kwkwc/gin-docs/testdata/api/user"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMerge(t *testing.T) {
+	c := (&Config{Title: "X", MethodsList: []string{"GET"}}).Default()
+	assert.Equal(t, "X", c.Title)
+	assert.Equal(t, []string{"GET"}, c.MethodsList)
+	assert.Equal(t, "1.0.0", c.Version)
+	assert.Equal(t, "/docs/api", c.UrlPrefix)
+	assert.True(t, c.Enable)
+	assert.Equal(t, 3, c.SampleLimit)
+}
+
+func TestZeroConfig(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	c := &Config{}
+	assert.NoError(t, ApiDoc{Ge: r, Conf: c}.OnlineHtml())
+	assert.True(t, c.Enable)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestNew(t *testing.T) {
+	r := gin.New()
+	r.GET("/get_data", GetData)
+	r.POST("/api/todo", AddTodo)
+
+	apiDoc, err := New(r,
+		WithTitle("Todo API", "2.0.0"),
+		WithPrefix("/docs"),
+		WithAuth("admin"),
+		WithGrouping(GroupByPath(1)),
+		WithConfig(func(c *Config) { c.AllMd = false }),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, r, apiDoc.Ge)
+	assert.Equal(t, "Todo API", apiDoc.Conf.Title)
+	assert.Equal(t, "2.0.0", apiDoc.Conf.Version)
+	assert.Equal(t, "/docs", apiDoc.Conf.UrlPrefix)
+	assert.Equal(t, "8c6976e5b5410415bde908bd4dee15dfb167a9c873fc4bb8a81f6f2ab448a918", apiDoc.Conf.PasswordSha2)
+	assert.False(t, apiDoc.Conf.AllMd)
+	assert.True(t, apiDoc.Conf.Enable)
+
+	assert.NoError(t, apiDoc.init())
+	dataMap := apiDoc.getApiData()
+	assert.Contains(t, dataMap, "get_data")
+	assert.Contains(t, dataMap, "api")
+	assert.Equal(t, "api", dataMap["api"]["children"][0]["router"])
+}
+
+func TestNewErrors(t *testing.T) {
+	_, err := New(nil)
+	assert.EqualError(t, err, "engine is nil")
+
+	e := Exporter{Export: func(w io.Writer, d ApiDoc) error { return nil }}
+	_, err = New(gin.New(),
+		WithTitle("", ""),
+		WithAuth(""),
+		WithGrouping(nil),
+		WithExporter("csv", e),
+		WithExporter("csv", e),
+		WithExporter("", e),
+	)
+	assert.EqualError(t, err, "`WithTitle` title is empty\n"+
+		"`WithAuth` password is empty\n"+
+		"`WithGrouping` group func is nil\n"+
+		"`WithExporter` exporter `csv` is duplicated\n"+
+		"`WithExporter` exporter `` needs a name and an export func")
+
+	_, err = New(gin.New(), WithPrefix("docs"))
+	assert.EqualError(t, err, "invalid config:\n`UrlPrefix` `docs` must start with `/`")
+}
+
+func TestGroupBy(t *testing.T) {
+	route := func(path string) gin.RouteInfo {
+		return gin.RouteInfo{Path: path, Handler: "github.com/kwkwc/gin-docs.AddTodo"}
+	}
+	assert.Equal(t, "gin-docs", GroupByPackage(route("/api/todo")))
+	assert.Equal(t, "api", GroupByPath(1)(route("/api/todo")))
+	assert.Equal(t, "api/todo", GroupByPath(2)(route("/api/:id/todo/*file")))
+	assert.Equal(t, "/", GroupByPath(1)(route("/")))
+	assert.Equal(t, "/", GroupByPath(2)(route("/:id")))
+}
+
+func TestGroupBySameName(t *testing.T) {
+	r := gin.New()
+	r.GET("/api/users", user.List)
+	r.POST("/api/users", user.List)
+	r.GET("/api/orders", order.List)
+
+	c := (&Config{}).Default()
+	c.GroupBy = GroupByPath(1)
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.init())
+
+	// The handlers of the same name are not merged, the routes of a handler are
+	children := apiDoc.apiData()["api"]["children"]
+	assert.Len(t, children, 2)
+	assert.Equal(t, "List", children[0]["name"])
+	assert.Equal(t, "/api/users\t[GET] /api/users\t[POST]", children[0]["url"])
+	assert.Equal(t, "List users", children[0]["name_extra"])
+	assert.Equal(t, "order.List", children[1]["name"])
+	assert.Equal(t, "/api/orders\t[GET]", children[1]["url"])
+	assert.Equal(t, "List orders", children[1]["name_extra"])
+}
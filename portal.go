@@ -0,0 +1,232 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Data of the services is fetched again after this time
+	serviceTTL     = time.Minute
+	serviceTimeout = 10 * time.Second
+)
+
+// DocService is a service aggregated by the portal, the documentation is
+// fetched from its url or read from an exported data file, and it is the
+// local engine when both are empty
+type DocService struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// Documentation url of the service, e.g. `http://user:8080/docs/api`
+	Url string `json:"url" yaml:"url" toml:"url"`
+	// `data` file exported by `OfflineHtml`, used instead of `Url`
+	DataFile string `json:"data_file" yaml:"data_file" toml:"data_file"`
+	// Headers of the requests to the service, e.g. `Auth-Password-SHA2`
+	Headers map[string]string `json:"headers" yaml:"headers" toml:"headers"`
+}
+
+type serviceData struct {
+	dataMap DataMap
+	fetched time.Time
+}
+
+var (
+	serviceMu     sync.Mutex
+	serviceMap    = make(map[string]serviceData)
+	serviceClient = &http.Client{Timeout: serviceTimeout}
+)
+
+func (d ApiDoc) findService(name string) (DocService, error) {
+	for _, s := range d.Conf.Services {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+
+	return DocService{}, fmt.Errorf("service `%s` does not exist", name)
+}
+
+func (d ApiDoc) serviceNames() []string {
+	names := []string{}
+	for _, s := range d.Conf.Services {
+		names = append(names, s.Name)
+	}
+
+	return names
+}
+
+// host returns the origin of the service, used by the debugger
+func (s DocService) host() string {
+	u, err := url.Parse(s.Url)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	return u.Scheme + "://" + u.Host
+}
+
+// fetch requests `/data` of the service
+func (s DocService) fetch() (DataMap, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(s.Url, "/")+"/data", nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := serviceClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("service `%s` responded %s", s.Name, resp.Status)
+	}
+
+	data := struct {
+		Data DataMap `json:"data"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, fmt.Errorf("invalid data of service `%s`: %s", s.Name, err)
+	}
+	if data.Data == nil {
+		data.Data = DataMap{}
+	}
+
+	return data.Data, nil
+}
+
+// serviceDataMap returns the API data of a service, the data fetched is cached for
+// `serviceTTL` and the last data is kept when the service is unavailable
+func (d ApiDoc) serviceDataMap(s DocService, version DocVersion) (DataMap, error) {
+	switch {
+	case s.DataFile != "":
+		return loadSnapshot(s.DataFile)
+	case s.Url == "":
+		dataMap, err := d.versionData(version, d.apiData())
+		if err != nil || version.DataFile != "" {
+			return dataMap, err
+		}
+		return d.addLiveData(dataMap), nil
+	}
+
+	serviceMu.Lock()
+	cached, ok := serviceMap[s.Url]
+	serviceMu.Unlock()
+	if ok && time.Since(cached.fetched) < serviceTTL {
+		return cached.dataMap, nil
+	}
+
+	dataMap, err := s.fetch()
+	if err != nil {
+		if ok {
+			slog.Warn(fmt.Sprintf("%s service `%s` err: %s\n", PROJECT_NAME, s.Name, err))
+			return cached.dataMap, nil
+		}
+		return nil, err
+	}
+
+	serviceMu.Lock()
+	serviceMap[s.Url] = serviceData{dataMap: dataMap, fetched: time.Now()}
+	serviceMu.Unlock()
+
+	return dataMap, nil
+}
+
+// portalData returns the API data of a service, or of all services merged when name
+// is empty, the groups of each service are prefixed by its name
+func (d ApiDoc) portalData(name string, version DocVersion) (DataMap, []string, error) {
+	if name != "" {
+		s, err := d.findService(name)
+		if err != nil {
+			return nil, nil, err
+		}
+		dataMap, err := d.serviceDataMap(s, version)
+		return dataMap, nil, err
+	}
+
+	dataMaps := make([]DataMap, len(d.Conf.Services))
+	errs := make([]error, len(d.Conf.Services))
+	var wg sync.WaitGroup
+	for i, s := range d.Conf.Services {
+		wg.Add(1)
+		go func(i int, s DocService) {
+			defer wg.Done()
+			dataMaps[i], errs[i] = d.serviceDataMap(s, version)
+		}(i, s)
+	}
+	wg.Wait()
+
+	merged := DataMap{}
+	errors := []string{}
+	for i, s := range d.Conf.Services {
+		if errs[i] != nil {
+			errors = append(errors, fmt.Sprintf("%s: %s", s.Name, errs[i]))
+			continue
+		}
+		for k, rm := range dataMaps[i] {
+			router := s.Name + "/" + k
+			children := []KVMap{}
+			for _, item := range rm["children"] {
+				newItem := KVMap{"service": s.Name}
+				for field, value := range item {
+					newItem[field] = value
+				}
+				newItem["router"] = router
+				children = append(children, newItem)
+			}
+			merged[router] = RouterMap{"children": children}
+		}
+	}
+
+	return merged, errors, nil
+}
+
+// portalBody returns the body of `/data` in portal mode
+func (d ApiDoc) portalBody(host, name string, version DocVersion) ([]byte, error) {
+	dataMap, errors, err := d.portalData(name, version)
+	if err != nil {
+		return nil, err
+	}
+	if name != "" {
+		if s, _ := d.findService(name); s.host() != "" {
+			host = s.host()
+		}
+	}
+
+	h, err := d.dataH(host, version, dataMap)
+	if err != nil {
+		return nil, err
+	}
+	h["service"] = name
+	h["errors"] = errors
+
+	return json.Marshal(h)
+}
+
+// servePortal serves `/data` of the services
+func (d ApiDoc) servePortal(c *gin.Context, host string, version DocVersion) {
+	name := c.Query("service")
+	if name != "" {
+		if _, err := d.findService(name); err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	body, err := d.portalBody(host, name, version)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	newCachedContent("application/json; charset=utf-8", body, time.Time{}).serve(c, dataCacheControl)
+}
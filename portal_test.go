@@ -0,0 +1,116 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupPortal(t *testing.T) (*gin.Engine, *httptest.Server) {
+	// A remote service protected by a password
+	remote := gin.New()
+	remote.DELETE("/delete_data", DeleteData)
+	rc := (&Config{}).Default()
+	rc.PasswordSha2 = "8c6976e5b5410415bde908bd4dee15dfb167a9c873fc4bb8a81f6f2ab448a918"
+	assert.NoError(t, ApiDoc{Ge: remote, Conf: rc}.OnlineHtml())
+	server := httptest.NewServer(remote)
+
+	// A service exported to a data file
+	snapshot := gin.New()
+	snapshot.PUT("/change_data", ChangeData)
+	sc := (&Config{}).Default()
+	dataMap := ApiDoc{Ge: snapshot, Conf: sc}.getApiData()
+	dataByte, _ := json.Marshal(map[string]any{"data": dataMap})
+	file := filepath.Join(t.TempDir(), "data")
+	assert.NoError(t, os.WriteFile(file, dataByte, 0644))
+
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	c := (&Config{}).Default()
+	c.Services = []DocService{
+		{Name: "portal"},
+		{Name: "remote", Url: server.URL + "/docs/api/", Headers: map[string]string{"Auth-Password-SHA2": rc.PasswordSha2}},
+		{Name: "archive", DataFile: file},
+		{Name: "down", Url: server.URL + "/missing"},
+	}
+	assert.NoError(t, ApiDoc{Ge: r, Conf: c}.OnlineHtml())
+
+	return r, server
+}
+
+func getPortalData(r *gin.Engine, service string) (int, map[string]any) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/data?service="+service, nil)
+	r.ServeHTTP(w, req)
+
+	data := map[string]any{}
+	json.Unmarshal(w.Body.Bytes(), &data)
+
+	return w.Code, data
+}
+
+func TestPortalAll(t *testing.T) {
+	r, server := setupPortal(t)
+	defer server.Close()
+
+	code, data := getPortalData(r, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []any{"portal", "remote", "archive", "down"}, data["services"])
+	assert.Len(t, data["errors"], 1)
+	assert.Contains(t, data["errors"].([]any)[0], "down: service `down` responded 404")
+
+	groups := data["data"].(map[string]any)
+	assert.Len(t, groups, 3)
+	for router, name := range map[string]string{
+		"portal/gin-docs":  "AddData",
+		"remote/gin-docs":  "DeleteData",
+		"archive/gin-docs": "ChangeData",
+	} {
+		item := groups[router].(map[string]any)["children"].([]any)[0].(map[string]any)
+		assert.Equal(t, name, item["name"])
+		assert.Equal(t, router, item["router"])
+	}
+}
+
+func TestPortalService(t *testing.T) {
+	r, server := setupPortal(t)
+	defer server.Close()
+
+	code, data := getPortalData(r, "remote")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, server.URL, data["host"])
+	assert.Equal(t, "remote", data["service"])
+	assert.Contains(t, dataJson(data), "/delete_data")
+
+	code, _ = getPortalData(r, "down")
+	assert.Equal(t, http.StatusBadGateway, code)
+
+	code, data = getPortalData(r, "unknown")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "service `unknown` does not exist", data["error"])
+}
+
+func TestPortalKeepsLastData(t *testing.T) {
+	r, server := setupPortal(t)
+
+	code, _ := getPortalData(r, "remote")
+	assert.Equal(t, http.StatusOK, code)
+
+	// Expire the data, the last data is kept while the service is down
+	server.Close()
+	serviceMu.Lock()
+	cached := serviceMap[server.URL+"/docs/api/"]
+	cached.fetched = cached.fetched.Add(-serviceTTL)
+	serviceMap[server.URL+"/docs/api/"] = cached
+	serviceMu.Unlock()
+
+	code, data := getPortalData(r, "remote")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, dataJson(data), "/delete_data")
+}
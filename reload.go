@@ -0,0 +1,205 @@
+package gin_docs
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const reloadInterval = time.Second
+
+// docMu guards the parsed documents and templates while they are reloaded
+var docMu sync.RWMutex
+
+// reloader notifies the open documentation pages of changed sources and templates,
+// the files are watched while a page is open
+type reloader struct {
+	mu      sync.Mutex
+	clients map[chan string]struct{}
+	// stop ends the watcher when the last page is closed
+	stop chan struct{}
+	// Modification times of the files when last watched
+	sourceTimes, templateTimes map[string]time.Time
+}
+
+// subscribe adds a page, the watcher of the docs is started for the first one
+func (rl *reloader) subscribe(d ApiDoc) chan string {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if len(rl.clients) == 0 {
+		rl.stop = make(chan struct{})
+		go rl.watch(d, rl.stop)
+	}
+	ch := make(chan string, 1)
+	rl.clients[ch] = struct{}{}
+
+	return ch
+}
+
+// unsubscribe removes a page, the watcher is stopped with the last one
+func (rl *reloader) unsubscribe(ch chan string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if _, ok := rl.clients[ch]; !ok {
+		return
+	}
+	delete(rl.clients, ch)
+	if len(rl.clients) == 0 {
+		close(rl.stop)
+	}
+}
+
+func (rl *reloader) broadcast(kind string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	for ch := range rl.clients {
+		select {
+		case ch <- kind:
+		default:
+		}
+	}
+}
+
+// watchFiles returns the handler source files with the docs of `Locales` beside them,
+// and the templates with the message catalogs
+func (d ApiDoc) watchFiles() (sources, templates []string) {
+	docMu.RLock()
+	defer docMu.RUnlock()
+
+	for k := range docMap {
+		sources = append(sources, k)
+	}
+	sort.Strings(sources)
+	sources = append(sources, d.sidecarFiles(sources)...)
+	for k := range templateMap {
+		templates = append(templates, filepath.Join(rootPath, "templates", k+".html"))
+	}
+	templates = append(templates, d.Conf.Theme.themeFiles()...)
+	templates = append(templates, d.uiLocaleFiles()...)
+
+	return sources, templates
+}
+
+func modTimes(files []string) map[string]time.Time {
+	times := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			times[file] = info.ModTime()
+		}
+	}
+
+	return times
+}
+
+// changedFiles returns the files whose modification time differs from before,
+// the files newly watched are not changed
+func changedFiles(before, after map[string]time.Time) []string {
+	changed := []string{}
+	for file, t := range after {
+		if bt, ok := before[file]; ok && !bt.Equal(t) {
+			changed = append(changed, file)
+		}
+	}
+
+	return changed
+}
+
+// reload re-parses the changed sources or re-reads the templates and rebuilds the data
+func (d ApiDoc) reload(sources []string, templates bool) error {
+	docMu.Lock()
+	if templates {
+		if err := d.readTemplate(rootPath); err != nil {
+			docMu.Unlock()
+			return err
+		}
+	}
+	for _, file := range sources {
+		delete(docMap, file)
+		delete(docDeclMap, file)
+	}
+	docMu.Unlock()
+
+	if templates {
+		d.state().invalidatePage()
+	}
+	if len(sources) > 0 {
+		d.state().invalidate()
+	}
+
+	return nil
+}
+
+// watch checks the files every `reloadInterval` until stop is closed, the files
+// changed while no page was open are reloaded first
+func (rl *reloader) watch(d ApiDoc, stop chan struct{}) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		rl.check(d)
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// check reloads the changed files and notifies the pages
+func (rl *reloader) check(d ApiDoc) {
+	// Files of the routes registered later are watched once their docs are parsed
+	sources, templates := d.watchFiles()
+	sourceTimes, templateTimes := modTimes(sources), modTimes(templates)
+
+	rl.mu.Lock()
+	changedSources := changedFiles(rl.sourceTimes, sourceTimes)
+	templatesChanged := len(changedFiles(rl.templateTimes, templateTimes)) > 0
+	rl.sourceTimes, rl.templateTimes = sourceTimes, templateTimes
+	rl.mu.Unlock()
+	if len(changedSources) == 0 && !templatesChanged {
+		return
+	}
+
+	if err := d.reload(changedSources, templatesChanged); err != nil {
+		slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+		return
+	}
+
+	if templatesChanged {
+		rl.broadcast("template")
+	} else {
+		rl.broadcast("data")
+	}
+}
+
+// liveReload watches the handler sources and templates in debug mode, and pushes
+// a `reload` event to the open pages over SSE when they change, the docs mounted
+// more than once share the watcher
+func (d ApiDoc) liveReload(docs gin.IRouter) {
+	rl := d.state().reloader()
+
+	docs.GET("/reload", func(c *gin.Context) {
+		ch := rl.subscribe(d)
+		defer rl.unsubscribe(ch)
+
+		c.Stream(func(w io.Writer) bool {
+			select {
+			case kind := <-ch:
+				c.SSEvent("reload", kind)
+				return true
+			case <-c.Request.Context().Done():
+				return false
+			}
+		})
+	})
+}
@@ -0,0 +1,114 @@
+package gin_docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChangedFiles(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "api.go")
+	assert.NoError(t, os.WriteFile(file, []byte("package api\n"), 0o644))
+	missing := filepath.Join(t.TempDir(), "missing.go")
+
+	before := modTimes([]string{file, missing})
+	assert.Len(t, before, 1)
+	assert.Empty(t, changedFiles(before, modTimes([]string{file, missing})))
+
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(file, later, later))
+	assert.Equal(t, []string{file}, changedFiles(before, modTimes([]string{file, missing})))
+}
+
+func TestReload(t *testing.T) {
+	r := gin.New()
+	r.POST("/get_data", GetData)
+
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.init())
+
+	assert.Len(t, apiDoc.apiData()["gin-docs"]["children"], 1)
+	r.GET(c.UrlPrefix+"/data", GetData)
+
+	file := handlerFile(GetData)
+	docMap[file]["GetData"] = "stale"
+	sources, _ := apiDoc.watchFiles()
+	assert.Contains(t, sources, file)
+
+	assert.NoError(t, apiDoc.reload([]string{file}, false))
+	assert.NotContains(t, docMap, file)
+
+	children := apiDoc.apiData()["gin-docs"]["children"]
+	assert.Len(t, children, 1)
+	assert.Equal(t, "/get_data\t[POST]", children[0]["url"])
+	assert.NotEqual(t, "stale", docMap[file]["GetData"])
+}
+
+func TestReloader(t *testing.T) {
+	apiDoc := ApiDoc{Ge: gin.New(), Conf: (&Config{}).Default()}
+	assert.NoError(t, apiDoc.init())
+
+	rl := &reloader{clients: make(map[chan string]struct{})}
+	ch := rl.subscribe(apiDoc)
+	stop := rl.stop
+
+	rl.broadcast("data")
+	// A client which is behind does not block the watcher
+	rl.broadcast("template")
+	assert.Equal(t, "data", <-ch)
+
+	rl.unsubscribe(ch)
+	rl.broadcast("data")
+	assert.Empty(t, ch)
+	// The watcher is stopped with the last page
+	_, open := <-stop
+	assert.False(t, open)
+	rl.unsubscribe(ch)
+}
+
+func TestReloaderWatch(t *testing.T) {
+	r := gin.New()
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	// The docs mounted more than once share the watcher
+	assert.NoError(t, apiDoc.OnlineHtml())
+	_, err := apiDoc.Handler()
+	assert.NoError(t, err)
+	rl := apiDoc.state().reloader()
+	assert.Nil(t, rl.stop)
+
+	file := filepath.Join(t.TempDir(), "api.go")
+	assert.NoError(t, os.WriteFile(file, []byte("package api\n"), 0o644))
+	docMu.Lock()
+	docMap[file] = KVMap{}
+	docMu.Unlock()
+
+	ch := rl.subscribe(apiDoc)
+	assert.Eventually(t, func() bool {
+		rl.mu.Lock()
+		defer rl.mu.Unlock()
+		_, ok := rl.sourceTimes[file]
+		return ok
+	}, 3*time.Second, 10*time.Millisecond)
+	rl.unsubscribe(ch)
+
+	// The files changed while no page is open are reloaded with the next one
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(file, later, later))
+	ch = rl.subscribe(apiDoc)
+	defer rl.unsubscribe(ch)
+	select {
+	case kind := <-ch:
+		assert.Equal(t, "data", kind)
+	case <-time.After(3 * time.Second):
+		t.Fatal("no reload")
+	}
+	docMu.RLock()
+	assert.NotContains(t, docMap, file)
+	docMu.RUnlock()
+}
@@ -0,0 +1,81 @@
+package gin_docs
+
+import (
+	"bytes"
+	"html/template"
+	"maps"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+)
+
+// htmlMarkdown renders the markdown of the docs to HTML, GFM with heading ids,
+// the raw HTML of a doc is kept for `htmlPolicy`
+var htmlMarkdown = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough, extension.Linkify, extension.TaskList,
+	),
+	goldmark.WithParserOptions(parser.WithAutoHeadingID()),
+	goldmark.WithRendererOptions(html.WithUnsafe()),
+)
+
+// htmlPolicy is the allowlist of the rendered HTML, scripts, event handlers and
+// `javascript:` urls of a doc are removed
+var htmlPolicy = func() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+#-]+$`)).OnElements("code")
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").OnElements("input")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+
+	return p
+}()
+
+// markdownToHtml renders markdown to sanitized HTML
+func markdownToHtml(md string) (template.HTML, error) {
+	var b bytes.Buffer
+	if err := htmlMarkdown.Convert([]byte(md), &b); err != nil {
+		return "", err
+	}
+
+	return template.HTML(htmlPolicy.SanitizeBytes(b.Bytes())), nil
+}
+
+// htmlKeys are the markdown of an API and the keys of their rendered HTML in `/data`
+var htmlKeys = [][2]string{
+	{"doc_md", "doc_html"},
+	{"sample_md", "sample_html"},
+	{"schema_md", "schema_html"},
+}
+
+// addHtml returns the data with the markdown of the APIs rendered to HTML,
+// e.g. `doc_html` of `doc_md`, the HTML of other sources is rendered again
+func addHtml(dataMap DataMap) (DataMap, error) {
+	newDataMap := make(DataMap, len(dataMap))
+	for router := range dataMap {
+		children := make([]KVMap, 0, len(dataMap[router]["children"]))
+		for _, item := range dataMap[router]["children"] {
+			newItem := maps.Clone(item)
+			for _, k := range htmlKeys {
+				delete(newItem, k[1])
+				if item[k[0]] == "" {
+					continue
+				}
+				h, err := markdownToHtml(item[k[0]])
+				if err != nil {
+					return nil, err
+				}
+				newItem[k[1]] = string(h)
+			}
+			children = append(children, newItem)
+		}
+		newDataMap[router] = RouterMap{"children": children}
+	}
+
+	return newDataMap, nil
+}
@@ -0,0 +1,65 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToHtml(t *testing.T) {
+	h, err := markdownToHtml("### request\n\n```json\n{\"name\": \"<b>\"}\n```\n\n| a | b |\n| :- | -: |\n| 1 | 2 |\n\n- [x] done")
+	assert.NoError(t, err)
+	assert.Contains(t, string(h), `<h3 id="request">request</h3>`)
+	assert.Contains(t, string(h), `<code class="language-json">{&#34;name&#34;: &#34;&lt;b&gt;&#34;}`)
+	assert.Contains(t, string(h), `<td align="left">1</td>`)
+	assert.Contains(t, string(h), `<input checked="" disabled="" type="checkbox"> done`)
+
+	// The HTML of a doc is sanitized
+	h, err = markdownToHtml("<details><summary>more</summary>text</details>\n\n" +
+		"<script>alert(1)</script>\n\n<img src=\"x\" onerror=\"alert(1)\">\n\n[link](javascript:alert(1)) <a href=\"/todo\" onclick=\"alert(1)\">todo</a>")
+	assert.NoError(t, err)
+	assert.Contains(t, string(h), "<details><summary>more</summary>text</details>")
+	assert.Contains(t, string(h), `<img src="x">`)
+	assert.Contains(t, string(h), `<a href="/todo" rel="nofollow">todo</a>`)
+	for _, s := range []string{"<script", "alert", "onerror", "onclick", "javascript:"} {
+		assert.NotContains(t, string(h), s)
+	}
+}
+
+func TestAddHtml(t *testing.T) {
+	dataMap := DataMap{"todo": RouterMap{"children": []KVMap{
+		{"name": "AddTodo", "doc_md": "**add**", "sample_md": "", "doc_html": "<script></script>"},
+		{"name": "GetTodo", "doc_md": "", "doc_html": "<script></script>"},
+	}}}
+	newDataMap, err := addHtml(dataMap)
+	assert.NoError(t, err)
+
+	children := newDataMap["todo"]["children"]
+	assert.Equal(t, "<p><strong>add</strong></p>\n", children[0]["doc_html"])
+	assert.NotContains(t, children[0], "sample_html")
+	assert.NotContains(t, children[1], "doc_html")
+	// The data is not changed
+	assert.Equal(t, "<script></script>", dataMap["todo"]["children"][0]["doc_html"])
+}
+
+func TestDataHtml(t *testing.T) {
+	apiDoc := setupCollection()
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/data", nil)
+	apiDoc.Ge.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	body := struct {
+		Data DataMap `json:"data"`
+	}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	addTodo := body.Data["todo"]["children"][0]
+	assert.Equal(t, "AddTodo", addTodo["name"])
+	assert.Contains(t, addTodo["doc_md"], "### request")
+	assert.Contains(t, addTodo["doc_html"], `<h3 id="request">request</h3>`)
+}
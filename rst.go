@@ -0,0 +1,292 @@
+package gin_docs
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/util"
+)
+
+// Underline characters of the sections, the title, groups, APIs, then the doc headings
+const rstUnderlines = "=-~^\"'"
+
+// rstWriter converts the markdown of a doc to reStructuredText
+type rstWriter struct {
+	src   []byte
+	ranks map[int]int
+}
+
+// markdownToRst converts markdown to reStructuredText in the section of an API
+func markdownToRst(md string) string {
+	doc, src := parseMarkdown(md)
+	w := rstWriter{src: src, ranks: headingRanks(doc)}
+
+	return w.blocks(doc)
+}
+
+// rstText escapes the markup of a text by backslashes
+func rstText(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case strings.ContainsRune("\\*`|", r):
+			b.WriteRune('\\')
+		case r == '_':
+			// `name_` is a reference and `_name` a target, `user_id` is text
+			if i == 0 || i == len(runes)-1 || !isWordRune(runes[i-1]) || !isWordRune(runes[i+1]) {
+				b.WriteRune('\\')
+			}
+		case r == ':' && i+1 < len(runes) && runes[i+1] == ':':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// rstLines escapes the lines which would start a block, e.g. `- text` or `1. text`
+func rstLines(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if l == "" {
+			continue
+		}
+		digits := strings.TrimLeft(l, "0123456789")
+		if strings.ContainsRune("-+#:.>=~^\"'", rune(l[0])) ||
+			(len(digits) < len(l) && (strings.HasPrefix(digits, ".") || strings.HasPrefix(digits, ")"))) {
+			lines[i] = "\\" + l
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// rstHeading returns a section title underlined to its width
+func rstHeading(title string, underline byte) string {
+	return title + "\n" + strings.Repeat(string(underline), max(textWidth(title), 1))
+}
+
+func (w rstWriter) blocks(n ast.Node) string {
+	blocks := []string{}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if b := w.block(c); b != "" {
+			blocks = append(blocks, b)
+		}
+	}
+
+	return strings.Join(blocks, "\n\n")
+}
+
+func (w rstWriter) block(n ast.Node) string {
+	switch n := n.(type) {
+	case *ast.Heading:
+		i := min(3+w.ranks[n.Level], len(rstUnderlines)-1)
+		return rstHeading(w.inline(n), rstUnderlines[i])
+	case *ast.Paragraph, *ast.TextBlock:
+		return rstLines(w.inline(n))
+	case *ast.FencedCodeBlock, *ast.CodeBlock:
+		lang := codeLanguage(n, w.src)
+		if lang == "" {
+			lang = "text"
+		}
+		code := strings.TrimSuffix(blockLines(n, w.src), "\n")
+		// A directive without content is an error
+		if strings.TrimSpace(code) == "" {
+			return ""
+		}
+		return ".. code-block:: " + lang + "\n\n" + indentLines(code, "   ")
+	case *ast.List:
+		items := []string{}
+		loose := false
+		for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+			marker := "- "
+			if n.IsOrdered() {
+				marker = strconv.Itoa(n.Start+len(items)) + ". "
+			}
+			item := w.blocks(c)
+			if strings.Contains(item, "\n\n") {
+				loose = true
+			}
+			indent := strings.Repeat(" ", len(marker))
+			items = append(items, marker+strings.TrimPrefix(indentLines(item, indent), indent))
+		}
+		if loose {
+			return strings.Join(items, "\n\n")
+		}
+		return strings.Join(items, "\n")
+	case *ast.Blockquote:
+		// The empty comment ends a list before the quote
+		return "..\n\n" + indentLines(w.blocks(n), "   ")
+	case *ast.HTMLBlock:
+		html := blockLines(n, w.src)
+		if n.HasClosure() {
+			html += string(n.ClosureLine.Value(w.src))
+		}
+		// The HTML is sanitized as on the page, it reaches the readers of the document
+		html = strings.TrimSpace(htmlPolicy.Sanitize(html))
+		if html == "" {
+			return ""
+		}
+		return ".. raw:: html\n\n" + indentLines(html, "   ")
+	case *east.Table:
+		rows := []string{}
+		for r := n.FirstChild(); r != nil; r = r.NextSibling() {
+			cells := []string{}
+			for c := r.FirstChild(); c != nil; c = c.NextSibling() {
+				cells = append(cells, strings.TrimRight("- "+w.inline(c), " "))
+			}
+			rows = append(rows, "* "+strings.Join(cells, "\n  "))
+		}
+		return ".. list-table::\n   :header-rows: 1\n\n" + indentLines(strings.Join(rows, "\n"), "   ")
+	}
+
+	// Transitions are not allowed at the end of a section
+	return ""
+}
+
+// rstPiece is a part of an inline text, the markup is separated from the words around it
+type rstPiece struct {
+	text   string
+	markup bool
+}
+
+func (w rstWriter) inline(n ast.Node) string {
+	pieces := w.pieces(n)
+
+	var b strings.Builder
+	for i, p := range pieces {
+		if p.markup && b.Len() > 0 {
+			last, _ := utf8.DecodeLastRuneInString(b.String())
+			if !unicode.IsSpace(last) && !strings.ContainsRune("'\"([{<-/:", last) {
+				b.WriteString("\\ ")
+			}
+		}
+		b.WriteString(p.text)
+		if p.markup && i+1 < len(pieces) {
+			next, _ := utf8.DecodeRuneInString(pieces[i+1].text)
+			if !unicode.IsSpace(next) && !strings.ContainsRune("'\")]}>-/:.,;!?\\", next) {
+				b.WriteString("\\ ")
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func (w rstWriter) pieces(n ast.Node) []rstPiece {
+	pieces := []rstPiece{}
+	var text strings.Builder
+	// The texts are escaped together, e.g. `user_id` is split at `_` by the parser
+	flush := func() {
+		if text.Len() > 0 {
+			pieces = append(pieces, rstPiece{text: rstText(text.String())})
+			text.Reset()
+		}
+	}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.Text:
+			text.Write(util.UnescapePunctuations(c.Segment.Value(w.src)))
+			if c.SoftLineBreak() || c.HardLineBreak() {
+				flush()
+				pieces = append(pieces, rstPiece{text: "\n"})
+			}
+			continue
+		case *ast.String:
+			text.Write(c.Value)
+			continue
+		}
+
+		flush()
+		switch c := c.(type) {
+		case *ast.CodeSpan:
+			code := plainText(c, w.src)
+			if strings.Contains(code, "``") || strings.TrimSpace(code) != code {
+				pieces = append(pieces, rstPiece{text: rstText(code)})
+			} else {
+				pieces = append(pieces, rstPiece{text: "``" + code + "``", markup: true})
+			}
+		case *ast.Emphasis:
+			marker := strings.Repeat("*", c.Level)
+			pieces = append(pieces, rstPiece{text: marker + rstText(strings.TrimSpace(plainText(c, w.src))) + marker, markup: true})
+		case *ast.Link:
+			pieces = append(pieces, rstLink(plainText(c, w.src), string(c.Destination)))
+		case *ast.Image:
+			pieces = append(pieces, rstLink(plainText(c, w.src), string(c.Destination)))
+		case *ast.AutoLink:
+			pieces = append(pieces, rstPiece{text: string(c.URL(w.src))})
+		case *ast.RawHTML:
+			for i := 0; i < c.Segments.Len(); i++ {
+				seg := c.Segments.At(i)
+				pieces = append(pieces, rstPiece{text: rstText(string(seg.Value(w.src)))})
+			}
+		default:
+			pieces = append(pieces, w.pieces(c)...)
+		}
+	}
+	flush()
+
+	return pieces
+}
+
+// rstLink returns an anonymous hyperlink, e.g. “ `text <https://example.com>`__ “
+func rstLink(text, target string) rstPiece {
+	text = strings.NewReplacer("\\", "\\\\", "`", "\\`", "<", "\\<").Replace(strings.TrimSpace(text))
+	if text == "" {
+		text = target
+	}
+
+	return rstPiece{text: "`" + text + " <" + target + ">`__", markup: true}
+}
+
+// rstExport writes the docs as a reStructuredText document, with a section for each group
+// and a label for each API, e.g. “:ref:`api-todo-addtodo` “
+func (d ApiDoc) rstExport(w io.Writer) error {
+	dataMap := d.addLiveData(d.apiData())
+
+	var b strings.Builder
+	title := rstText(d.title())
+	line := strings.Repeat(string(rstUnderlines[0]), textWidth(title))
+	fmt.Fprintf(&b, "%s\n%s\n%s\n\n:Version: %s\n\n", line, title, line, rstText(d.Conf.Version))
+	if d.description() != "" {
+		b.WriteString(rstLines(rstText(d.description())) + "\n\n")
+	}
+
+	for _, group := range sortedGroups(dataMap) {
+		fmt.Fprintf(&b, ".. _%s:\n\n%s\n\n", anchorId(group), rstHeading(rstText(group), rstUnderlines[1]))
+		for _, item := range dataMap[group]["children"] {
+			fmt.Fprintf(&b, ".. _%s:\n\n%s\n\n", anchorId(group, item["name"]),
+				rstHeading(rstText(itemTitle(item)), rstUnderlines[2]))
+
+			for _, r := range itemRoutes(item) {
+				b.WriteString("- ``" + r + "``\n")
+			}
+			b.WriteString("\n")
+
+			if doc := d.itemPlainDoc(item); doc != "" {
+				b.WriteString("::\n\n" + indentLines(strings.Trim(doc, "\n"), "   ") + "\n\n")
+			}
+			for _, k := range []string{"doc_md", "sample_md", "schema_md"} {
+				if item[k] != "" {
+					b.WriteString(markdownToRst(item[k]) + "\n\n")
+				}
+			}
+		}
+	}
+
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+
+	return err
+}
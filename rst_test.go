@@ -0,0 +1,53 @@
+package gin_docs
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToRst(t *testing.T) {
+	assert.Equal(t, "args\n^^^^\n\n"+
+		".. list-table::\n   :header-rows: 1\n\n   * - name\n     - note\n   * - id\n     - a \\| b\n\n"+
+		"例子\n\"\"\"\"",
+		markdownToRst("### args\n\n| name | note |\n|---|---|\n| id | a \\| b |\n\n##### 例子"))
+
+	assert.Equal(t, "See `the docs <https://example.com>`__, ``id`` and **bold**\\ text.",
+		markdownToRst("See [the *docs*](https://example.com), `id` and **bold**text."))
+
+	// The markup of the text is escaped
+	assert.Equal(t, "user_id is name\\_ \\*a\\* \\|x\\| \\:: end", markdownToRst("user_id is name_ \\*a\\* |x| :: end"))
+	assert.Equal(t, "\\- not a list\n\\1. not a list", markdownToRst("\\- not a list\n1\\. not a list"))
+
+	assert.Equal(t, "- one\n\n  - nested\n\n- two\n\n3. first\n4. second",
+		markdownToRst("- one\n  - nested\n- two\n\n3. first\n4. second"))
+	assert.Equal(t, ".. code-block:: json\n\n   {\"a\": 1}", markdownToRst("```json\n{\"a\": 1}\n```"))
+	assert.Equal(t, "- item\n\n..\n\n   quote", markdownToRst("- item\n\n> quote"))
+	assert.Equal(t, "text", markdownToRst("```json\n```\n\ntext"))
+
+	// The raw HTML blocks are sanitized, the inline HTML is text
+	assert.Equal(t, ".. raw:: html\n\n   <details><summary>more</summary><img src=\"x.png\"></details>\n\n"+
+		"a <b onclick=\"alert(1)\">b</b>",
+		markdownToRst("<details><summary>more</summary><img src=\"x.png\" onerror=\"alert(1)\"></details>\n\n"+
+			"a <b onclick=\"alert(1)\">b</b>"))
+	assert.Equal(t, "", markdownToRst("<script>alert(1)</script>"))
+}
+
+func TestRstExport(t *testing.T) {
+	r := gin.New()
+	r.POST("/todo", AddTodo)
+	c := (&Config{}).Default()
+	c.Title = "Todo API"
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, ApiDoc{Ge: r, Conf: c}.Export("rst", buf))
+	rst := buf.String()
+
+	assert.Contains(t, rst, "========\nTodo API\n========\n\n:Version: 1.0.0\n\n"+
+		".. _api-gin-docs:\n\ngin-docs\n--------\n\n"+
+		".. _api-gin-docs-addtodo:\n\nAddTodo(Add todo)\n~~~~~~~~~~~~~~~~~\n\n"+
+		"- ``POST /todo``\n\n"+
+		"request\n^^^^^^^\n\n.. code-block:: json\n\n")
+}
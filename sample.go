@@ -0,0 +1,431 @@
+package gin_docs
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+const redactedText = "[REDACTED]"
+
+type Sample struct {
+	Method          string            `json:"method"`
+	Path            string            `json:"path"`
+	Url             string            `json:"url"`
+	Status          int               `json:"status"`
+	Time            time.Time         `json:"time"`
+	Duration        time.Duration     `json:"duration"`
+	RequestHeaders  map[string]string `json:"request_headers"`
+	RequestBody     string            `json:"request_body"`
+	ResponseHeaders map[string]string `json:"response_headers"`
+	ResponseBody    string            `json:"response_body"`
+}
+
+func (s Sample) key() string {
+	return sampleKey(s.Method, s.Path)
+}
+
+func sampleKey(method, path string) string {
+	return method + " " + path
+}
+
+type SampleConfig struct {
+	// Sampling rate between 0 and 1, default `1`
+	Rate float64
+	// Maximum size of a captured body in bytes, bodies are not captured if `0`, default `4096`
+	MaxBodySize int
+	// Header names to redact, default `[]string{"Authorization", "Cookie", "Set-Cookie", "Auth-Password-SHA2"}`
+	RedactHeaders []string
+	// JSON field names to redact in bodies and query params, e.g. `[]string{"password", "token"}`
+	RedactFields []string
+}
+
+func (sc *SampleConfig) Default() *SampleConfig {
+	sc.Rate = 1
+	sc.MaxBodySize = 4096
+	sc.RedactHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Auth-Password-SHA2"}
+
+	return sc
+}
+
+func (sc *SampleConfig) redactHeaders(header map[string][]string) map[string]string {
+	headers := make(map[string]string, len(header))
+	for k, v := range header {
+		if slices.ContainsFunc(sc.RedactHeaders, func(h string) bool {
+			return strings.EqualFold(h, k)
+		}) {
+			headers[k] = redactedText
+		} else {
+			headers[k] = strings.Join(v, ", ")
+		}
+	}
+
+	return headers
+}
+
+// fieldPatterns returns the patterns of `RedactFields` in a body which is not valid JSON
+func (sc *SampleConfig) fieldPatterns() []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(sc.RedactFields))
+	for _, field := range sc.RedactFields {
+		patterns = append(patterns, regexp.MustCompile(
+			`(?i)("`+regexp.QuoteMeta(field)+`"\s*:\s*)("(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`,
+		))
+	}
+
+	return patterns
+}
+
+// redactBody redacts the fields of a body, patterns are those of `fieldPatterns`
+func (sc *SampleConfig) redactBody(body []byte, patterns []*regexp.Regexp) string {
+	if len(sc.RedactFields) == 0 || len(body) == 0 {
+		return sc.capBody(string(body))
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err == nil {
+		if b, err := json.Marshal(sc.redactValue(v)); err == nil {
+			return sc.capBody(string(b))
+		}
+	}
+
+	// Not JSON or truncated JSON, fall back to pattern replacement
+	bodyStr := string(body)
+	for _, re := range patterns {
+		bodyStr = re.ReplaceAllString(bodyStr, `${1}"`+redactedText+`"`)
+	}
+
+	return sc.capBody(bodyStr)
+}
+
+func (sc *SampleConfig) redactValue(v any) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k := range vv {
+			if slices.ContainsFunc(sc.RedactFields, func(f string) bool {
+				return strings.EqualFold(f, k)
+			}) {
+				vv[k] = redactedText
+			} else {
+				vv[k] = sc.redactValue(vv[k])
+			}
+		}
+	case []any:
+		for i := range vv {
+			vv[i] = sc.redactValue(vv[i])
+		}
+	}
+
+	return v
+}
+
+// capBody cuts a body at `MaxBodySize`, before the character it falls in
+func (sc *SampleConfig) capBody(body string) string {
+	if sc.MaxBodySize > 0 && len(body) > sc.MaxBodySize {
+		n := sc.MaxBodySize
+		for n > 0 && !utf8.RuneStart(body[n]) {
+			n--
+		}
+		return body[:n] + "..."
+	}
+
+	return body
+}
+
+// redactUrl redacts the query params of `RedactFields` in a url, the other params are kept as they are
+func (sc *SampleConfig) redactUrl(rawUrl string) string {
+	path, query, ok := strings.Cut(rawUrl, "?")
+	if !ok || len(sc.RedactFields) == 0 {
+		return rawUrl
+	}
+
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		rawName, _, _ := strings.Cut(pair, "=")
+		name, err := url.QueryUnescape(rawName)
+		if err != nil {
+			name = rawName
+		}
+		if slices.ContainsFunc(sc.RedactFields, func(f string) bool {
+			return strings.EqualFold(f, name)
+		}) {
+			pairs[i] = rawName + "=" + url.QueryEscape(redactedText)
+		}
+	}
+
+	return path + "?" + strings.Join(pairs, "&")
+}
+
+type SampleStore interface {
+	// Save stores a captured sample
+	Save(s Sample) error
+	// Recent returns up to n of the most recent samples of a route, newest first
+	Recent(method, path string, n int) ([]Sample, error)
+}
+
+// MemorySampleStore keeps the latest samples of each route in a ring buffer
+type MemorySampleStore struct {
+	size    int
+	mu      sync.RWMutex
+	samples map[string][]Sample
+	next    map[string]int
+}
+
+func NewMemorySampleStore(size int) *MemorySampleStore {
+	if size <= 0 {
+		size = 10
+	}
+
+	return &MemorySampleStore{
+		size:    size,
+		samples: make(map[string][]Sample),
+		next:    make(map[string]int),
+	}
+}
+
+func (m *MemorySampleStore) Save(s Sample) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	k := s.key()
+	if len(m.samples[k]) < m.size {
+		m.samples[k] = append(m.samples[k], s)
+	} else {
+		m.samples[k][m.next[k]] = s
+	}
+	m.next[k] = (m.next[k] + 1) % m.size
+
+	return nil
+}
+
+func (m *MemorySampleStore) Recent(method, path string, n int) ([]Sample, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	k := sampleKey(method, path)
+	ring := m.samples[k]
+	result := []Sample{}
+	for i := 1; i <= len(ring) && len(result) < n; i++ {
+		result = append(result, ring[(m.next[k]-i+len(ring))%len(ring)])
+	}
+
+	return result, nil
+}
+
+// FileSampleStore appends samples to a local file as JSON lines, the file is rotated
+// to `<path>.1` when it exceeds `MaxSize`
+type FileSampleStore struct {
+	// Size in bytes the file is rotated at, the previous rotated file is removed, default `8 MiB`
+	MaxSize int64
+
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSampleStore(path string) *FileSampleStore {
+	return &FileSampleStore{MaxSize: 8 << 20, path: path}
+}
+
+func (f *FileSampleStore) Save(s Sample) error {
+	line, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	if info, err := file.Stat(); err == nil && f.MaxSize > 0 && info.Size() > f.MaxSize {
+		return os.Rename(f.path, f.path+".1")
+	}
+
+	return nil
+}
+
+func (f *FileSampleStore) Recent(method, path string, n int) ([]Sample, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	k := sampleKey(method, path)
+	samples := []Sample{}
+	// The rotated file has the older samples
+	for _, p := range []string{f.path + ".1", f.path} {
+		var err error
+		if samples, err = readSamples(p, k, n, samples); err != nil {
+			return nil, err
+		}
+	}
+	slices.Reverse(samples)
+
+	return samples, nil
+}
+
+// readSamples appends the samples of a route in a file to samples, keeping the last n
+func readSamples(path, k string, n int, samples []Sample) ([]Sample, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return samples, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var s Sample
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil || s.key() != k {
+			continue
+		}
+		samples = append(samples, s)
+		if len(samples) > n {
+			samples = samples[1:]
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return samples, nil
+}
+
+// BoltSampleStore keeps samples in an embedded bbolt database, one bucket per route
+type BoltSampleStore struct {
+	db   *bolt.DB
+	size int
+}
+
+func NewBoltSampleStore(path string, size int) (*BoltSampleStore, error) {
+	if size <= 0 {
+		size = 10
+	}
+
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	return &BoltSampleStore{db: db, size: size}, nil
+}
+
+func (b *BoltSampleStore) Close() error {
+	return b.db.Close()
+}
+
+func (b *BoltSampleStore) Save(s Sample) error {
+	value, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(s.key()))
+		if err != nil {
+			return err
+		}
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		key := make([]byte, 8)
+		binary.BigEndian.PutUint64(key, seq)
+		if err := bucket.Put(key, value); err != nil {
+			return err
+		}
+
+		if seq <= uint64(b.size) {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, _ := c.First(); k != nil && binary.BigEndian.Uint64(k) <= seq-uint64(b.size); k, _ = c.First() {
+			if err := bucket.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *BoltSampleStore) Recent(method, path string, n int) ([]Sample, error) {
+	samples := []Sample{}
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket([]byte(sampleKey(method, path)))
+		if bucket == nil {
+			return nil
+		}
+		c := bucket.Cursor()
+		for k, v := c.Last(); k != nil && len(samples) < n; k, v = c.Prev() {
+			var s Sample
+			if err := json.Unmarshal(v, &s); err != nil {
+				return fmt.Errorf("decode sample `%s`: %w", sampleKey(method, path), err)
+			}
+			samples = append(samples, s)
+		}
+		return nil
+	})
+
+	return samples, err
+}
+
+func (s Sample) bodyLang(headers map[string]string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, "Content-Type") && strings.Contains(v, "json") {
+			return "json"
+		}
+	}
+
+	return ""
+}
+
+func (s Sample) markdown() string {
+	md := fmt.Sprintf(
+		"#### `%s %s` %d\n\n> %s, %s\n\n",
+		s.Method, s.Url, s.Status, s.Time.Format(time.DateTime), s.Duration.Round(time.Microsecond),
+	)
+	if s.RequestBody != "" {
+		fence := codeFence(s.RequestBody)
+		md += "request\n\n" + fence + s.bodyLang(s.RequestHeaders) + "\n" + s.RequestBody + "\n" + fence + "\n\n"
+	}
+	if s.ResponseBody != "" {
+		fence := codeFence(s.ResponseBody)
+		md += "response\n\n" + fence + s.bodyLang(s.ResponseHeaders) + "\n" + s.ResponseBody + "\n" + fence + "\n\n"
+	}
+
+	return md
+}
+
+// codeFence returns a code fence longer than the runs of backticks in the content
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+
+	return strings.Repeat("`", max(3, longest+1))
+}
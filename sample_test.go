@@ -0,0 +1,231 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSampleRouter(store SampleStore, sc *SampleConfig) *gin.Engine {
+	r := gin.New()
+	r.Use(RecordSamples(store, sc))
+
+	r.POST("/add_data", func(c *gin.Context) {
+		var body map[string]any
+		_ = c.ShouldBindJSON(&body)
+		c.JSON(http.StatusOK, gin.H{"token": "secret", "name": body["name"]})
+	})
+
+	return r
+}
+
+func TestRecordSamples(t *testing.T) {
+	store := NewMemorySampleStore(2)
+	sc := (&SampleConfig{}).Default()
+	sc.RedactFields = []string{"password", "token"}
+	r := setupSampleRouter(store, sc)
+
+	for _, name := range []string{"a", "b", "c"} {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest(
+			"POST", "/add_data?x=1&Token=abc&password=p%40ss",
+			strings.NewReader(`{"name": "`+name+`", "password": "123"}`),
+		)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer xxx")
+		r.ServeHTTP(w, req)
+		assert.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"name":"`+name+`"`)
+	}
+
+	samples, err := store.Recent("POST", "/add_data", 10)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 2)
+	assert.Equal(t, "/add_data?x=1&Token=%5BREDACTED%5D&password=%5BREDACTED%5D", samples[0].Url)
+	assert.Equal(t, 200, samples[0].Status)
+	assert.Equal(t, redactedText, samples[0].RequestHeaders["Authorization"])
+	assert.JSONEq(t, `{"name": "c", "password": "[REDACTED]"}`, samples[0].RequestBody)
+	assert.JSONEq(t, `{"name": "c", "token": "[REDACTED]"}`, samples[0].ResponseBody)
+	assert.Contains(t, samples[1].RequestBody, `"b"`)
+}
+
+func TestRedactBodyPatterns(t *testing.T) {
+	sc := (&SampleConfig{}).Default()
+	sc.RedactFields = []string{"password", "a.b"}
+	patterns := sc.fieldPatterns()
+	assert.Len(t, patterns, 2)
+
+	// Truncated JSON is redacted by the patterns
+	assert.Equal(t, `{"Password": "[REDACTED]", "a.b": "[REDACTED]", "axb": 1, "name": "x`,
+		sc.redactBody([]byte(`{"Password": "123", "a.b": 2, "axb": 1, "name": "x`), patterns))
+}
+
+func TestRecordSamplesBodyCap(t *testing.T) {
+	store := NewMemorySampleStore(1)
+	sc := (&SampleConfig{}).Default()
+	sc.MaxBodySize = 10
+	sc.RedactFields = []string{"password"}
+	r := setupSampleRouter(store, sc)
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		"POST", "/add_data", strings.NewReader(`{"password": "123456789", "name": "abc"}`),
+	)
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"name":"abc"`)
+
+	samples, err := store.Recent("POST", "/add_data", 1)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 1)
+	assert.NotContains(t, samples[0].RequestBody, "123")
+	assert.True(t, strings.HasSuffix(samples[0].RequestBody, "..."))
+}
+
+func TestCapBody(t *testing.T) {
+	sc := &SampleConfig{MaxBodySize: 4}
+	// The body is not cut inside a character
+	assert.Equal(t, "ab...", sc.capBody("ab中文"))
+	assert.Equal(t, "ab中...", (&SampleConfig{MaxBodySize: 5}).capBody("ab中文"))
+	assert.Equal(t, "abcd...", sc.capBody("abcde"))
+	assert.Equal(t, "abcd", sc.capBody("abcd"))
+}
+
+func TestRedactUrl(t *testing.T) {
+	sc := (&SampleConfig{}).Default()
+	assert.Equal(t, "/todo?token=x", sc.redactUrl("/todo?token=x"))
+
+	sc.RedactFields = []string{"token", "api key"}
+	assert.Equal(t, "/todo", sc.redactUrl("/todo"))
+	assert.Equal(t, "/todo?a=1&token=%5BREDACTED%5D&api+key=%5BREDACTED%5D&b&token=%5BREDACTED%5D",
+		sc.redactUrl("/todo?a=1&token=x&api+key=y&b&token"))
+}
+
+func TestRecordSamplesRate(t *testing.T) {
+	store := NewMemorySampleStore(1)
+	r := setupSampleRouter(store, &SampleConfig{Rate: 0})
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", "/add_data", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	samples, err := store.Recent("POST", "/add_data", 1)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 0)
+}
+
+func TestFileSampleStore(t *testing.T) {
+	store := NewFileSampleStore(filepath.Join(t.TempDir(), "samples.jsonl"))
+	for _, status := range []int{200, 201, 202} {
+		assert.NoError(t, store.Save(Sample{Method: "GET", Path: "/a", Status: status}))
+	}
+	assert.NoError(t, store.Save(Sample{Method: "GET", Path: "/b", Status: 500}))
+
+	samples, err := store.Recent("GET", "/a", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{202, 201}, []int{samples[0].Status, samples[1].Status})
+}
+
+func TestFileSampleStoreRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "samples.jsonl")
+	store := NewFileSampleStore(path)
+	line, err := json.Marshal(Sample{Method: "GET", Path: "/a", Status: 200})
+	assert.NoError(t, err)
+	// Rotated after 4 samples
+	store.MaxSize = int64(len(line)+1)*4 - 1
+	for status := 200; status < 210; status++ {
+		assert.NoError(t, store.Save(Sample{Method: "GET", Path: "/a", Status: status}))
+	}
+
+	// 200-203 are removed, 204-207 are rotated
+	info, err := os.Stat(path + ".1")
+	assert.NoError(t, err)
+	assert.Equal(t, store.MaxSize+1, info.Size())
+
+	// The samples of the rotated file are read after the current ones
+	samples, err := store.Recent("GET", "/a", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{209, 208, 207}, []int{samples[0].Status, samples[1].Status, samples[2].Status})
+	samples, err = store.Recent("GET", "/a", 100)
+	assert.NoError(t, err)
+	assert.Len(t, samples, 6)
+	assert.Equal(t, 204, samples[5].Status)
+}
+
+func TestSampleMarkdown(t *testing.T) {
+	s := Sample{Method: "POST", Url: "/md", Status: 200, RequestBody: "````go\nfmt.Println(\"`x`\")\n````"}
+	md := s.markdown()
+	assert.Contains(t, md, "request\n\n`````\n````go\n")
+	assert.Contains(t, md, "\n````\n`````\n\n")
+
+	s = Sample{Method: "GET", Url: "/md", Status: 200, ResponseBody: `{"a": 1}`,
+		ResponseHeaders: map[string]string{"Content-Type": "application/json"}}
+	assert.Contains(t, s.markdown(), "response\n\n```json\n{\"a\": 1}\n```\n\n")
+}
+
+func TestBoltSampleStore(t *testing.T) {
+	store, err := NewBoltSampleStore(filepath.Join(t.TempDir(), "samples.db"), 2)
+	assert.NoError(t, err)
+	defer store.Close()
+
+	for _, status := range []int{200, 201, 202} {
+		assert.NoError(t, store.Save(Sample{Method: "GET", Path: "/a", Status: status}))
+	}
+
+	samples, err := store.Recent("GET", "/a", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(samples))
+	assert.Equal(t, []int{202, 201}, []int{samples[0].Status, samples[1].Status})
+}
+
+func TestOnlineHtmlDataSamples(t *testing.T) {
+	store := NewMemorySampleStore(5)
+	r := setupRouter()
+	r.Use(RecordSamples(store, nil))
+	r.GET("/get_data", AddData)
+
+	c := (&Config{}).Default()
+	c.Samples = store
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/get_data?id=1", nil)
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", "/docs/api/data", nil)
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+
+	var data struct {
+		Data DataMap `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	sampleMd := ""
+	for _, item := range data.Data["gin-docs"]["children"] {
+		if item["name"] == "AddData" {
+			sampleMd = item["sample_md"]
+		}
+	}
+	assert.Contains(t, sampleMd, "`GET /get_data?id=1` 200")
+
+	err = apiDoc.OfflineMarkdown("doc_samples.md", true)
+	assert.NoError(t, err)
+	md, err := os.ReadFile("doc_samples.md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(md), "### samples")
+
+	err = os.RemoveAll("doc_samples.md")
+	assert.NoError(t, err)
+}
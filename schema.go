@@ -0,0 +1,522 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Maximum distinct string values remembered for enum detection
+	schemaEnumLimit = 5
+	// Minimum observations of each enum value before a string becomes an enum
+	schemaEnumRepeat = 2
+	// Maximum distinct keys of an object, beyond which it is a map, e.g. keyed by IDs
+	schemaPropertyLimit = 100
+)
+
+var (
+	uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	dateRegexp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	uriRegexp  = regexp.MustCompile(`^https?://\S+$`)
+)
+
+type schemaNode struct {
+	Count       int
+	Types       map[string]int
+	ObjectCount int
+	Properties  map[string]*schemaNode
+	// Values of an object with too many keys, its properties are merged into it
+	MapValues   *schemaNode
+	Items       *schemaNode
+	StringCount int
+	Values      map[string]int
+	TooMany     bool
+	Formats     map[string]int
+	Sensitive   bool
+}
+
+func newSchemaNode() *schemaNode {
+	return &schemaNode{
+		Types:      make(map[string]int),
+		Properties: make(map[string]*schemaNode),
+		Values:     make(map[string]int),
+		Formats:    make(map[string]int),
+	}
+}
+
+func (n *schemaNode) observe(v any, redactFields []string) {
+	n.Count++
+	switch vv := v.(type) {
+	case nil:
+		n.Types["null"]++
+	case bool:
+		n.Types["boolean"]++
+	case float64:
+		if vv == math.Trunc(vv) {
+			n.Types["integer"]++
+		} else {
+			n.Types["number"]++
+		}
+	case string:
+		n.Types["string"]++
+		n.StringCount++
+		for _, format := range stringFormats(vv) {
+			n.Formats[format]++
+		}
+		if n.Sensitive || n.TooMany {
+			break
+		}
+		n.Values[vv]++
+		if len(n.Values) > schemaEnumLimit {
+			n.TooMany = true
+			n.Values = make(map[string]int)
+		}
+	case []any:
+		n.Types["array"]++
+		if n.Items == nil {
+			n.Items = newSchemaNode()
+		}
+		for _, item := range vv {
+			n.Items.observe(item, redactFields)
+		}
+	case map[string]any:
+		n.Types["object"]++
+		n.ObjectCount++
+		for k, item := range vv {
+			if n.MapValues == nil && n.Properties[k] == nil && len(n.Properties) >= schemaPropertyLimit {
+				n.MapValues = newSchemaNode()
+				for _, p := range n.Properties {
+					n.MapValues.merge(p)
+				}
+				n.Properties = make(map[string]*schemaNode)
+			}
+			if n.MapValues != nil {
+				n.MapValues.Sensitive = n.MapValues.Sensitive || slices.ContainsFunc(redactFields, func(f string) bool {
+					return strings.EqualFold(f, k)
+				})
+				n.MapValues.observe(item, redactFields)
+				continue
+			}
+			if n.Properties[k] == nil {
+				n.Properties[k] = newSchemaNode()
+				n.Properties[k].Sensitive = slices.ContainsFunc(redactFields, func(f string) bool {
+					return strings.EqualFold(f, k)
+				})
+			}
+			n.Properties[k].observe(item, redactFields)
+		}
+	}
+}
+
+// merge adds the observations of o, e.g. of the properties of an object which becomes a map
+func (n *schemaNode) merge(o *schemaNode) {
+	n.Count += o.Count
+	n.ObjectCount += o.ObjectCount
+	n.StringCount += o.StringCount
+	n.Sensitive = n.Sensitive || o.Sensitive
+	for t, count := range o.Types {
+		n.Types[t] += count
+	}
+	for format, count := range o.Formats {
+		n.Formats[format] += count
+	}
+
+	n.TooMany = n.TooMany || o.TooMany
+	if !n.TooMany {
+		for v, count := range o.Values {
+			n.Values[v] += count
+		}
+		if len(n.Values) > schemaEnumLimit {
+			n.TooMany = true
+		}
+	}
+	if n.TooMany || n.Sensitive {
+		n.Values = make(map[string]int)
+	}
+
+	if o.Items != nil {
+		if n.Items == nil {
+			n.Items = newSchemaNode()
+		}
+		n.Items.merge(o.Items)
+	}
+	if o.MapValues != nil {
+		if n.MapValues == nil {
+			n.MapValues = newSchemaNode()
+		}
+		n.MapValues.merge(o.MapValues)
+	}
+	for k, p := range o.Properties {
+		if n.Properties[k] == nil {
+			n.Properties[k] = newSchemaNode()
+		}
+		n.Properties[k].merge(p)
+	}
+}
+
+func stringFormats(s string) []string {
+	formats := []string{}
+	if _, err := time.Parse(time.RFC3339, s); err == nil {
+		formats = append(formats, "date-time")
+	}
+	if dateRegexp.MatchString(s) {
+		if _, err := time.Parse(time.DateOnly, s); err == nil {
+			formats = append(formats, "date")
+		}
+	}
+	if uuidRegexp.MatchString(s) {
+		formats = append(formats, "uuid")
+	}
+	if addr, err := mail.ParseAddress(s); err == nil && addr.Address == s {
+		formats = append(formats, "email")
+	}
+	if uriRegexp.MatchString(s) {
+		formats = append(formats, "uri")
+	}
+
+	return formats
+}
+
+func (n *schemaNode) typeNames() []string {
+	types := []string{}
+	for t := range n.Types {
+		// integer is a subset of number
+		if t == "integer" && n.Types["number"] > 0 {
+			continue
+		}
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+func (n *schemaNode) format() string {
+	for _, format := range []string{"date-time", "date", "uuid", "email", "uri"} {
+		if n.StringCount > 0 && n.Formats[format] == n.StringCount {
+			return format
+		}
+	}
+
+	return ""
+}
+
+func (n *schemaNode) enum() []string {
+	if n.TooMany || n.Sensitive || len(n.Values) == 0 || n.format() != "" {
+		return nil
+	}
+	enum := []string{}
+	for v, count := range n.Values {
+		if count < schemaEnumRepeat {
+			return nil
+		}
+		enum = append(enum, v)
+	}
+	sort.Strings(enum)
+
+	return enum
+}
+
+func (n *schemaNode) required() []string {
+	required := []string{}
+	for k, p := range n.Properties {
+		if p.Count == n.ObjectCount {
+			required = append(required, k)
+		}
+	}
+	sort.Strings(required)
+
+	return required
+}
+
+func (n *schemaNode) jsonSchema() map[string]any {
+	schema := map[string]any{}
+
+	types := n.typeNames()
+	if len(types) == 1 {
+		schema["type"] = types[0]
+	} else if len(types) > 1 {
+		schema["type"] = types
+	}
+
+	if n.Types["string"] > 0 {
+		if format := n.format(); format != "" {
+			schema["format"] = format
+		}
+		if enum := n.enum(); enum != nil {
+			schema["enum"] = enum
+		}
+	}
+
+	if n.Types["object"] > 0 && n.MapValues != nil {
+		schema["additionalProperties"] = n.MapValues.jsonSchema()
+	} else if n.Types["object"] > 0 {
+		properties := map[string]any{}
+		for k, p := range n.Properties {
+			properties[k] = p.jsonSchema()
+		}
+		schema["properties"] = properties
+		if required := n.required(); len(required) > 0 {
+			schema["required"] = required
+		}
+	}
+
+	if n.Types["array"] > 0 && n.Items != nil && n.Items.Count > 0 {
+		schema["items"] = n.Items.jsonSchema()
+	}
+
+	return schema
+}
+
+type routeSchema struct {
+	Request  *schemaNode
+	Response *schemaNode
+}
+
+// SchemaStore infers JSON schemas of each route from the traffic observed by `InferSchemas`
+type SchemaStore struct {
+	mu     sync.RWMutex
+	routes map[string]*routeSchema
+}
+
+func NewSchemaStore() *SchemaStore {
+	return &SchemaStore{routes: make(map[string]*routeSchema)}
+}
+
+// Observe records the JSON bodies of one exchange, nil bodies are skipped
+func (s *SchemaStore) Observe(method, path string, request, response any, redactFields []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	k := sampleKey(method, path)
+	if s.routes[k] == nil {
+		s.routes[k] = &routeSchema{Request: newSchemaNode(), Response: newSchemaNode()}
+	}
+	if request != nil {
+		s.routes[k].Request.observe(request, redactFields)
+	}
+	if response != nil {
+		s.routes[k].Response.observe(response, redactFields)
+	}
+}
+
+func (s *SchemaStore) node(method, path, part string) (*schemaNode, error) {
+	rs := s.routes[sampleKey(method, path)]
+	if rs == nil {
+		return nil, fmt.Errorf("no schema observed for `%s`", sampleKey(method, path))
+	}
+
+	var node *schemaNode
+	switch part {
+	case "request":
+		node = rs.Request
+	case "response":
+		node = rs.Response
+	default:
+		return nil, fmt.Errorf("unknown schema part `%s`, use `request` or `response`", part)
+	}
+	if node.Count == 0 {
+		return nil, fmt.Errorf("no %s schema observed for `%s`", part, sampleKey(method, path))
+	}
+
+	return node, nil
+}
+
+// JSONSchema returns the inferred JSON Schema of the `request` or `response` part of a route
+func (s *SchemaStore) JSONSchema(method, path, part string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node, err := s.node(method, path, part)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := node.jsonSchema()
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["title"] = fmt.Sprintf("%s %s %s", method, path, part)
+
+	return json.MarshalIndent(schema, "", "    ")
+}
+
+// GoStruct returns a starting Go type declaration for the `request` or `response` part of a route
+func (s *SchemaStore) GoStruct(method, path, part, name string) (string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node, err := s.node(method, path, part)
+	if err != nil {
+		return "", err
+	}
+
+	if name == "" {
+		name = goIdentifier(strings.ToLower(method) + "_" + path + "_" + part)
+	}
+
+	decls := []string{}
+	typeName := goType(node, name, &decls)
+	if typeName != name {
+		decls = append(decls, fmt.Sprintf("type %s %s\n", name, typeName))
+	}
+	slices.Reverse(decls)
+
+	return strings.Join(decls, "\n"), nil
+}
+
+func goType(n *schemaNode, name string, decls *[]string) string {
+	types := slices.DeleteFunc(n.typeNames(), func(t string) bool { return t == "null" })
+	if len(types) != 1 {
+		return "any"
+	}
+	nullable := n.Types["null"] > 0
+
+	switch types[0] {
+	case "string":
+		if n.format() == "date-time" {
+			if nullable {
+				return "*time.Time"
+			}
+			return "time.Time"
+		}
+		if nullable {
+			return "*string"
+		}
+		return "string"
+	case "integer":
+		if nullable {
+			return "*int64"
+		}
+		return "int64"
+	case "number":
+		if nullable {
+			return "*float64"
+		}
+		return "float64"
+	case "boolean":
+		if nullable {
+			return "*bool"
+		}
+		return "bool"
+	case "array":
+		if n.Items == nil || n.Items.Count == 0 {
+			return "[]any"
+		}
+		return "[]" + goType(n.Items, name+"Item", decls)
+	case "object":
+		if n.MapValues != nil {
+			return "map[string]" + goType(n.MapValues, name+"Value", decls)
+		}
+		keys := make([]string, 0, len(n.Properties))
+		for k := range n.Properties {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "type %s struct {\n", name)
+		// Keys such as `a-b` and `a_b` are the same identifier, the fields are numbered
+		used := map[string]bool{}
+		for _, k := range keys {
+			p := n.Properties[k]
+			field := goIdentifier(k)
+			for i := 2; used[field]; i++ {
+				field = goIdentifier(k) + strconv.Itoa(i)
+			}
+			used[field] = true
+			tag := k
+			if p.Count < n.ObjectCount {
+				tag += ",omitempty"
+			}
+			fmt.Fprintf(&buf, "\t%s %s `json:\"%s\"`\n", field, goType(p, name+field, decls), tag)
+		}
+		buf.WriteString("}\n")
+		*decls = append(*decls, buf.String())
+
+		if nullable {
+			return "*" + name
+		}
+		return name
+	}
+
+	return "any"
+}
+
+var goInitialisms = []string{"ID", "URL", "URI", "UUID", "API", "HTTP", "JSON", "IP"}
+
+func goIdentifier(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	ident := ""
+	for _, w := range words {
+		if slices.Contains(goInitialisms, strings.ToUpper(w)) {
+			ident += strings.ToUpper(w)
+		} else {
+			r, size := utf8.DecodeRuneInString(w)
+			ident += string(unicode.ToUpper(r)) + w[size:]
+		}
+	}
+	// A field is exported only if it starts with an upper case letter, e.g. not `名前`
+	if r, _ := utf8.DecodeRuneInString(ident); !unicode.IsUpper(r) {
+		ident = "X" + ident
+	}
+
+	return ident
+}
+
+func (s *SchemaStore) markdown(method, path string) string {
+	md := ""
+	for _, part := range []string{"request", "response"} {
+		schema, err := s.JSONSchema(method, path, part)
+		if err != nil {
+			continue
+		}
+		md += fmt.Sprintf("#### `%s %s` %s\n\n```json\n%s\n```\n\n", method, path, part, schema)
+	}
+
+	return md
+}
+
+// InferSchemas observes JSON request and response bodies of each route into store
+func InferSchemas(store *SchemaStore, sc *SampleConfig) gin.HandlerFunc {
+	if sc == nil {
+		sc = (&SampleConfig{}).Default()
+	}
+
+	return func(c *gin.Context) {
+		captureExchange(c, sc, func(s Sample, reqBody, respBody []byte) {
+			var request, response any
+			for _, v := range []struct {
+				body []byte
+				dest *any
+			}{{reqBody, &request}, {respBody, &response}} {
+				// Skip empty and truncated bodies
+				if len(v.body) == 0 || len(v.body) > sc.MaxBodySize {
+					continue
+				}
+				if err := json.Unmarshal(v.body, v.dest); err != nil {
+					*v.dest = nil
+				}
+			}
+			if request == nil && response == nil {
+				return
+			}
+			store.Observe(s.Method, s.Path, request, response, sc.RedactFields)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferSchemas(t *testing.T) {
+	store := NewSchemaStore()
+	r := gin.New()
+	r.Use(InferSchemas(store, nil))
+	r.POST("/todo/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "done": false})
+	})
+
+	for _, body := range []string{
+		`{"name": "a", "type": "code", "due": "2024-05-13T10:00:00Z", "tags": [{"k": 1}], "owner": "a@b.com"}`,
+		`{"name": "b", "type": "code", "due": "2024-05-14T10:00:00Z", "tags": [], "note": "x"}`,
+		`{"name": "c", "type": "life", "due": "2024-05-15T10:00:00Z", "tags": [{"k": 2.5}]}`,
+		`{"name": "d", "type": "life", "due": "2024-05-16T10:00:00Z", "tags": null}`,
+	} {
+		w := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", "/todo/550e8400-e29b-41d4-a716-446655440000", strings.NewReader(body))
+		assert.NoError(t, err)
+		r.ServeHTTP(w, req)
+		assert.Equal(t, 200, w.Code)
+	}
+
+	schemaByte, err := store.JSONSchema("POST", "/todo/:id", "request")
+	assert.NoError(t, err)
+
+	var schema map[string]any
+	assert.NoError(t, json.Unmarshal(schemaByte, &schema))
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, []any{"due", "name", "tags", "type"}, schema["required"])
+
+	properties := schema["properties"].(map[string]any)
+	assert.Equal(t, []any{"code", "life"}, properties["type"].(map[string]any)["enum"])
+	assert.Nil(t, properties["name"].(map[string]any)["enum"])
+	assert.Equal(t, "date-time", properties["due"].(map[string]any)["format"])
+	assert.Equal(t, "email", properties["owner"].(map[string]any)["format"])
+	assert.Equal(t, []any{"array", "null"}, properties["tags"].(map[string]any)["type"])
+	assert.Equal(t, "number",
+		properties["tags"].(map[string]any)["items"].(map[string]any)["properties"].(map[string]any)["k"].(map[string]any)["type"],
+	)
+
+	schemaByte, err = store.JSONSchema("POST", "/todo/:id", "response")
+	assert.NoError(t, err)
+	assert.Contains(t, string(schemaByte), `"format": "uuid"`)
+
+	goStruct, err := store.GoStruct("POST", "/todo/:id", "request", "TodoRequest")
+	assert.NoError(t, err)
+	assert.Contains(t, goStruct, "type TodoRequest struct {")
+	assert.Contains(t, goStruct, "Due time.Time `json:\"due\"`")
+	assert.Contains(t, goStruct, "Note string `json:\"note,omitempty\"`")
+	assert.Contains(t, goStruct, "Tags []TodoRequestTagsItem `json:\"tags\"`")
+	assert.Contains(t, goStruct, "K float64 `json:\"k\"`")
+
+	_, err = store.JSONSchema("GET", "/todo/:id", "request")
+	assert.EqualError(t, err, "no schema observed for `GET /todo/:id`")
+}
+
+func TestGoIdentifier(t *testing.T) {
+	assert.Equal(t, "UserID", goIdentifier("user_id"))
+	assert.Equal(t, "X2fa", goIdentifier("2fa"))
+	assert.Equal(t, "X", goIdentifier("-"))
+	assert.Equal(t, "Élan", goIdentifier("élan"))
+	assert.Equal(t, "X名前", goIdentifier("名前"))
+	assert.Equal(t, "UserНазвание", goIdentifier("user_название"))
+
+	store := NewSchemaStore()
+	store.Observe("POST", "/todo", map[string]any{"名前": "a", "ñame": "b"}, nil, nil)
+	goStruct, err := store.GoStruct("POST", "/todo", "request", "Todo")
+	assert.NoError(t, err)
+	assert.Contains(t, goStruct, "X名前 string `json:\"名前\"`")
+	assert.Contains(t, goStruct, "Ñame string `json:\"ñame\"`")
+
+	// The keys of the same identifier are numbered, so the struct compiles
+	store.Observe("POST", "/user", map[string]any{"a-b": 1.0, "a_b": 2.0, "a b": 3.0}, nil, nil)
+	goStruct, err = store.GoStruct("POST", "/user", "request", "User")
+	assert.NoError(t, err)
+	assert.Contains(t, goStruct, "AB int64 `json:\"a b\"`")
+	assert.Contains(t, goStruct, "AB2 int64 `json:\"a-b\"`")
+	assert.Contains(t, goStruct, "AB3 int64 `json:\"a_b\"`")
+}
+
+func TestInferSchemasMap(t *testing.T) {
+	store := NewSchemaStore()
+	// An object keyed by IDs becomes a map instead of growing with each key
+	for i := 0; i < schemaPropertyLimit+50; i++ {
+		store.Observe("GET", "/todos", map[string]any{
+			"todos": map[string]any{fmt.Sprintf("id-%d", i): map[string]any{"name": "a", "done": i%2 == 0}},
+		}, nil, []string{"secret"})
+	}
+	store.Observe("GET", "/todos", map[string]any{
+		"todos": map[string]any{"secret": map[string]any{"name": "b", "done": true}},
+	}, nil, []string{"secret"})
+
+	todos := store.routes[sampleKey("GET", "/todos")].Request.Properties["todos"]
+	assert.Empty(t, todos.Properties)
+	assert.Equal(t, schemaPropertyLimit+51, todos.MapValues.Count)
+	assert.True(t, todos.MapValues.Sensitive)
+
+	schemaByte, err := store.JSONSchema("GET", "/todos", "request")
+	assert.NoError(t, err)
+	var schema map[string]any
+	assert.NoError(t, json.Unmarshal(schemaByte, &schema))
+	values := schema["properties"].(map[string]any)["todos"].(map[string]any)["additionalProperties"].(map[string]any)
+	assert.Equal(t, "object", values["type"])
+	assert.Equal(t, []any{"done", "name"}, values["required"])
+
+	goStruct, err := store.GoStruct("GET", "/todos", "request", "Todos")
+	assert.NoError(t, err)
+	assert.Contains(t, goStruct, "Todos map[string]TodosTodosValue `json:\"todos\"`")
+	assert.Contains(t, goStruct, "type TodosTodosValue struct {")
+}
+
+func TestInferSchemasRedactFields(t *testing.T) {
+	store := NewSchemaStore()
+	for i := 0; i < 3; i++ {
+		store.Observe("POST", "/login", map[string]any{"password": "same"}, nil, []string{"password"})
+	}
+
+	schemaByte, err := store.JSONSchema("POST", "/login", "request")
+	assert.NoError(t, err)
+	assert.NotContains(t, string(schemaByte), "same")
+}
+
+func TestOnlineHtmlSchema(t *testing.T) {
+	store := NewSchemaStore()
+	store.Observe("POST", "/add_data", map[string]any{"name": "x"}, nil, nil)
+
+	r := setupRouter()
+	c := (&Config{}).Default()
+	c.Schemas = store
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/docs/api/schema?method=POST&path=/add_data&part=request&format=go", nil)
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Body.String(), "type PostAddDataRequest struct {")
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", "/docs/api/schema?method=POST&path=/add_data&part=response", nil)
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, 404, w.Code)
+
+	w = httptest.NewRecorder()
+	req, err = http.NewRequest("GET", "/docs/api/data", nil)
+	assert.NoError(t, err)
+	r.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"schema_md":"### schema`)
+}
@@ -0,0 +1,349 @@
+package gin_docs
+
+import (
+	"html"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	searchLimit   = 20
+	snippetLength = 120
+)
+
+// Weights of the fields of an API in the search score
+var searchFields = []struct {
+	name   string
+	weight float64
+}{
+	{"name", 5},
+	{"name_extra", 4},
+	{"url", 3},
+	{"doc", 1},
+	{"doc_md", 1},
+}
+
+type SearchResult struct {
+	Router    string  `json:"router"`
+	Name      string  `json:"name"`
+	NameExtra string  `json:"name_extra"`
+	Url       string  `json:"url"`
+	Score     float64 `json:"score"`
+	// Escaped HTML with the matches in `<mark>`
+	Snippet string `json:"snippet"`
+}
+
+// searchIndex is an inverted index of the APIs of a DataMap
+type searchIndex struct {
+	items []KVMap
+	// Weighted term frequency by item
+	terms map[string]map[int]float64
+	// Sorted terms for prefix matching
+	sortedTerms []string
+}
+
+func isCJK(r rune) bool {
+	// The prolonged sound mark `ー` is not in the Katakana script
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) || r == 'ー'
+}
+
+// tokenize splits text into lower case words, camel case words are split as well,
+// CJK text has no spaces and is split into bigrams
+func tokenize(text string) []string {
+	tokens := []string{}
+	word, cjk := []rune{}, []rune{}
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		lower := strings.ToLower(string(word))
+		tokens = append(tokens, lower)
+		// `GetTodo` is found by `get` and `todo`
+		start := 0
+		parts := []string{}
+		for i := 1; i < len(word); i++ {
+			if unicode.IsUpper(word[i]) && unicode.IsLower(word[i-1]) {
+				parts = append(parts, strings.ToLower(string(word[start:i])))
+				start = i
+			}
+		}
+		if start > 0 {
+			parts = append(parts, strings.ToLower(string(word[start:])))
+			tokens = append(tokens, parts...)
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		if len(cjk) == 1 {
+			tokens = append(tokens, string(cjk))
+		}
+		for i := 0; i+1 < len(cjk); i++ {
+			tokens = append(tokens, string(cjk[i:i+2]))
+		}
+		cjk = cjk[:0]
+	}
+
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+
+	return tokens
+}
+
+func newSearchIndex(dataMap DataMap) *searchIndex {
+	idx := &searchIndex{terms: make(map[string]map[int]float64)}
+
+	routers := make([]string, 0, len(dataMap))
+	for router := range dataMap {
+		routers = append(routers, router)
+	}
+	sort.Strings(routers)
+	for _, router := range routers {
+		for _, item := range dataMap[router]["children"] {
+			id := len(idx.items)
+			idx.items = append(idx.items, item)
+			for _, f := range searchFields {
+				for _, token := range tokenize(item[f.name]) {
+					if idx.terms[token] == nil {
+						idx.terms[token] = make(map[int]float64)
+					}
+					idx.terms[token][id] += f.weight
+				}
+			}
+		}
+	}
+
+	for term := range idx.terms {
+		idx.sortedTerms = append(idx.sortedTerms, term)
+	}
+	sort.Strings(idx.sortedTerms)
+
+	return idx
+}
+
+// match returns the score of the items matching a query token, the terms
+// starting with the token are matched with a lower score
+func (idx *searchIndex) match(token string) map[int]float64 {
+	scores := map[int]float64{}
+	n := float64(len(idx.items))
+	i := sort.SearchStrings(idx.sortedTerms, token)
+	for ; i < len(idx.sortedTerms) && strings.HasPrefix(idx.sortedTerms[i], token); i++ {
+		term := idx.sortedTerms[i]
+		boost := 1.0
+		if term != token {
+			boost = 0.5
+		}
+		idf := math.Log(1 + n/float64(len(idx.terms[term])))
+		for id, tf := range idx.terms[term] {
+			scores[id] = max(scores[id], tf*idf*boost)
+		}
+	}
+
+	return scores
+}
+
+// search returns the items matching all tokens of the query by score
+func (idx *searchIndex) search(q string, limit int) []SearchResult {
+	tokens := tokenize(q)
+	if len(tokens) == 0 {
+		return []SearchResult{}
+	}
+
+	var scores map[int]float64
+	for _, token := range tokens {
+		matched := idx.match(token)
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for id := range scores {
+			if s, ok := matched[id]; ok {
+				scores[id] += s
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	results := []SearchResult{}
+	for id, score := range scores {
+		item := idx.items[id]
+		body := item["doc_md"]
+		if body == "" {
+			body = item["doc"]
+		}
+		results = append(results, SearchResult{
+			Router:    item["router"],
+			Name:      item["name"],
+			NameExtra: item["name_extra"],
+			Url:       item["url"],
+			Score:     math.Round(score*1000) / 1000,
+			Snippet:   snippet(body, strings.Fields(q)),
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		if results[i].Router != results[j].Router {
+			return results[i].Router < results[j].Router
+		}
+		return results[i].Name < results[j].Name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// snippet returns the escaped text around the first match with the matches highlighted
+func snippet(text string, words []string) string {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// Ranges of the matches in runes
+	type span struct{ start, end int }
+	spans := []span{}
+	for _, w := range words {
+		needle := []rune(strings.ToLower(w))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if string(lower[i:i+len(needle)]) == string(needle) {
+				spans = append(spans, span{i, i + len(needle)})
+				i += len(needle) - 1
+			}
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	start := 0
+	if len(spans) > 0 {
+		start = max(0, spans[0].start-snippetLength/3)
+		// Start at a word
+		for i := start; start > 0 && i < spans[0].start; i++ {
+			if runes[i] == ' ' {
+				start = i + 1
+				break
+			}
+		}
+	}
+	end := min(len(runes), start+snippetLength)
+
+	var sb strings.Builder
+	if start > 0 {
+		sb.WriteString("...")
+	}
+	pos := start
+	for _, s := range spans {
+		if s.start < pos || s.end > end {
+			continue
+		}
+		sb.WriteString(html.EscapeString(string(runes[pos:s.start])))
+		sb.WriteString("<mark>" + html.EscapeString(string(runes[s.start:s.end])) + "</mark>")
+		pos = s.end
+	}
+	sb.WriteString(html.EscapeString(string(runes[pos:end])))
+	if end < len(runes) {
+		sb.WriteString("...")
+	}
+
+	return sb.String()
+}
+
+// versionIndex returns the search index of a version in the locale, it is built again when the API data changes
+func (d ApiDoc) versionIndex(v DocVersion) (*searchIndex, error) {
+	s := d.state()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	live := d.loadApiData(s)
+	key := v.Name + "\n" + d.Conf.lang
+	if idx := s.indexes[key]; idx != nil {
+		return idx, nil
+	}
+
+	dataMap, err := d.versionData(v, live)
+	if err != nil {
+		return nil, err
+	}
+	if s.indexes == nil {
+		s.indexes = make(map[string]*searchIndex)
+	}
+	s.indexes[key] = newSearchIndex(dataMap)
+
+	return s.indexes[key], nil
+}
+
+// Search returns the APIs of the default version matching the query,
+// in names, urls, summaries and docs
+func (d ApiDoc) Search(q string, limit int) ([]SearchResult, error) {
+	v, err := d.findVersion("")
+	if err != nil {
+		return nil, err
+	}
+	idx, err := d.versionIndex(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return idx.search(q, limit), nil
+}
+
+// serveSearch serves `/search?q=` of a version, or of the services in portal mode
+func (d ApiDoc) serveSearch(c *gin.Context) {
+	v, err := d.findVersion(c.Query("version"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	limit := searchLimit
+	if n, err := strconv.Atoi(c.Query("limit")); err == nil && n > 0 {
+		limit = n
+	}
+
+	var idx *searchIndex
+	if len(d.Conf.Services) > 0 {
+		name := c.Query("service")
+		if name != "" {
+			if _, err := d.findService(name); err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+				return
+			}
+		}
+		dataMap, _, err := d.portalData(name, v)
+		if err != nil {
+			c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+			return
+		}
+		idx = newSearchIndex(dataMap)
+	} else if idx, err = d.versionIndex(v); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"q": c.Query("q"), "results": idx.search(c.Query("q"), limit)})
+}
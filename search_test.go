@@ -0,0 +1,104 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"gettodo", "get", "todo", "api", "v1", "todo"}, tokenize("GetTodo /api/v1/todo"))
+	assert.Equal(t, []string{"添加", "加任", "任务", "name", "删"}, tokenize("添加任务(name) 删"))
+	assert.Equal(t, []string{"json", "ユー", "ーザ"}, tokenize("JSON ユーザ"))
+}
+
+func newTestIndex() *searchIndex {
+	return newSearchIndex(DataMap{
+		"todo": RouterMap{"children": []KVMap{
+			{"name": "AddTodo", "name_extra": "添加任务", "url": "/api/todo\t[POST]", "router": "todo",
+				"doc_md": "### args\n| name | required |\n\nCreate a <todo> item with a name"},
+			{"name": "GetTodo", "name_extra": "Get todo", "url": "/api/todo\t[GET]", "router": "todo",
+				"doc_md": "Returns the todo by name"},
+		}},
+		"user": RouterMap{"children": []KVMap{
+			{"name": "GetUser", "name_extra": "获取用户", "url": "/api/user\t[GET]", "router": "user",
+				"doc_md": "Returns the user, see the todo list of the user"},
+		}},
+	})
+}
+
+func TestSearch(t *testing.T) {
+	idx := newTestIndex()
+
+	names := func(results []SearchResult) []string {
+		ns := []string{}
+		for _, r := range results {
+			ns = append(ns, r.Name)
+		}
+		return ns
+	}
+
+	// Names score higher than docs
+	results := idx.search("todo", 0)
+	assert.Equal(t, []string{"GetTodo", "AddTodo", "GetUser"}, names(results))
+	assert.Greater(t, results[0].Score, results[2].Score)
+
+	// All tokens must match, the last ones by prefix
+	assert.Equal(t, []string{"GetTodo", "AddTodo"}, names(idx.search("todo nam", 0)))
+	assert.Equal(t, []string{"GetUser"}, names(idx.search("use", 0)))
+	assert.Equal(t, []string{"AddTodo"}, names(idx.search("任务", 0)))
+	assert.Equal(t, []string{"GetUser"}, names(idx.search("用", 0)))
+	assert.Empty(t, idx.search("missing", 0))
+	assert.Empty(t, idx.search("  ", 0))
+	assert.Len(t, idx.search("todo", 1), 1)
+
+	assert.Equal(t, "### args | name | required | Create a &lt;<mark>todo</mark>&gt; item with a name",
+		idx.search("todo", 0)[1].Snippet)
+}
+
+func TestSnippet(t *testing.T) {
+	text := ""
+	for range 20 {
+		text += "lorem ipsum "
+	}
+	text += "Todo and todo"
+	assert.Equal(t, "...lorem ipsum lorem ipsum lorem ipsum <mark>Todo</mark> and <mark>todo</mark>",
+		snippet(text, []string{"todo"}))
+	assert.Equal(t, "a b", snippet("a\n\nb", []string{"x"}))
+}
+
+func TestSearchApi(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	r.PUT("/change_data", ChangeData)
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/search?q=change", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	data := struct {
+		Q       string         `json:"q"`
+		Results []SearchResult `json:"results"`
+	}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &data))
+	assert.Equal(t, "change", data.Q)
+	assert.Len(t, data.Results, 1)
+	assert.Equal(t, "ChangeData", data.Results[0].Name)
+
+	results, err := apiDoc.Search("add", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "AddData", results[0].Name)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/docs/api/search?q=change&version=9", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
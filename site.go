@@ -0,0 +1,285 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// markdownPlainText returns the text of markdown without markup, e.g. for the search index
+func markdownPlainText(md string) string {
+	doc, src := parseMarkdown(md)
+
+	var b strings.Builder
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.Paragraph, *ast.TextBlock, *ast.Heading:
+			b.WriteString(plainText(n, src) + "\n")
+			return ast.WalkSkipChildren, nil
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			b.WriteString(blockLines(n, src))
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+var slugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// siteSlug returns the name of a page of the site, e.g. `addtodo`
+func siteSlug(s, fallback string) string {
+	slug := strings.Trim(slugRegexp.ReplaceAllString(strings.ToLower(s), "-"), "-")
+	if slug == "" {
+		return fallback
+	}
+
+	return slug
+}
+
+// uniqueSlug returns the slug, or the slug with a number if it is used, e.g. `todo-2`
+func uniqueSlug(slug string, used map[string]bool) string {
+	unique := slug
+	for i := 2; used[unique]; i++ {
+		unique = slug + "-" + strconv.Itoa(i)
+	}
+	used[unique] = true
+
+	return unique
+}
+
+type siteApi struct {
+	Name    string
+	Summary string
+	Url     string
+	Routes  []string
+	Doc     string
+	Content template.HTML
+	text    string
+}
+
+type siteGroup struct {
+	Name string
+	Url  string
+	Apis []*siteApi
+}
+
+// sitePage is the data of the `site` template
+type sitePage struct {
+	ProjectName    string
+	ProjectVersion string
+	// Relative path to the root of the site, e.g. `../`
+	Root        string
+	Lang        string
+	Title       string
+	Version     string
+	Description string
+	// Title of the page, empty for the index
+	Page   string
+	Groups []*siteGroup
+	Group  *siteGroup
+	Api    *siteApi
+}
+
+// siteSearchEntry is an API in `search-index.json`
+type siteSearchEntry struct {
+	Name    string   `json:"name"`
+	Summary string   `json:"summary"`
+	Group   string   `json:"group"`
+	Routes  []string `json:"routes"`
+	Url     string   `json:"url"`
+	Text    string   `json:"text"`
+}
+
+type sitemapUrl struct {
+	Loc string `xml:"loc"`
+}
+
+type sitemapUrlSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Urls    []sitemapUrl `xml:"url"`
+}
+
+// siteGroups returns the groups of the site with their APIs, the markdown is rendered
+func (d ApiDoc) siteGroups() ([]*siteGroup, error) {
+	dataMap := d.addLiveData(d.apiData())
+
+	groups := []*siteGroup{}
+	usedGroups := map[string]bool{}
+	for _, name := range sortedGroups(dataMap) {
+		g := &siteGroup{Name: name}
+		dir := uniqueSlug(siteSlug(name, "group"), usedGroups)
+		g.Url = dir + "/index.html"
+
+		usedApis := map[string]bool{"index": true}
+		for _, item := range dataMap[name]["children"] {
+			md := []string{}
+			for _, k := range []string{"doc_md", "sample_md", "schema_md"} {
+				if item[k] != "" {
+					md = append(md, item[k])
+				}
+			}
+			content, err := markdownToHtml(strings.Join(md, "\n\n"))
+			if err != nil {
+				return nil, err
+			}
+
+			api := &siteApi{
+				Name:    item["name"],
+				Summary: item["name_extra"],
+				Url:     dir + "/" + uniqueSlug(siteSlug(item["name"], "api"), usedApis) + ".html",
+				Routes:  itemRoutes(item),
+				Doc:     d.itemPlainDoc(item),
+				Content: content,
+			}
+			api.text = strings.TrimSpace(strings.Join(strings.Fields(api.Doc), " ") + " " + markdownPlainText(item["doc_md"]))
+			g.Apis = append(g.Apis, api)
+		}
+		groups = append(groups, g)
+	}
+
+	return groups, nil
+}
+
+// sitemap returns the `sitemap.xml` of the pages of the site at `SiteUrl`
+func (d ApiDoc) sitemap(pages []string) ([]byte, error) {
+	base := strings.TrimSuffix(d.Conf.SiteUrl, "/") + "/"
+	set := sitemapUrlSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9"}
+	for _, p := range pages {
+		set.Urls = append(set.Urls, sitemapUrl{Loc: base + p})
+	}
+
+	out, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// OfflineSite generates a static site of pre-rendered pages, an index, a page for each
+// group and each API, with `search-index.json` and `sitemap.xml` if `SiteUrl` is set
+func (d ApiDoc) OfflineSite(out string, force bool) (err error) {
+	if out == "" {
+		out = "site"
+	}
+
+	if err := d.init(); err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("site").Parse(templateMap["site"])
+	if err != nil {
+		return err
+	}
+	groups, err := d.siteGroups()
+	if err != nil {
+		return err
+	}
+
+	dest := filepath.Join(".", out)
+	if ok, _ := pathExists(dest); ok {
+		if !force {
+			return fmt.Errorf("target `%s` exists, set `force=true` to override.", dest)
+		}
+		if err := os.RemoveAll(dest); err != nil {
+			return err
+		}
+	}
+	if err := os.Mkdir(dest, os.ModePerm); err != nil {
+		return err
+	}
+
+	pages := []string{}
+	writePage := func(name string, page sitePage) error {
+		page.ProjectName = PROJECT_NAME
+		page.ProjectVersion = PROJECT_VERSION
+		page.Lang = d.locale()
+		page.Title = d.title()
+		page.Version = d.Conf.Version
+		if page.Description == "" {
+			page.Description = d.description()
+		}
+		page.Groups = groups
+		if strings.Contains(name, "/") {
+			page.Root = "../"
+		}
+
+		var b bytes.Buffer
+		if err := tmpl.Execute(&b, page); err != nil {
+			return err
+		}
+		pages = append(pages, name)
+
+		return os.WriteFile(filepath.Join(dest, filepath.FromSlash(name)), b.Bytes(), 0644)
+	}
+
+	if err := writePage("index.html", sitePage{}); err != nil {
+		return err
+	}
+	entries := []siteSearchEntry{}
+	for _, g := range groups {
+		if err := os.Mkdir(filepath.Join(dest, filepath.Dir(filepath.FromSlash(g.Url))), os.ModePerm); err != nil {
+			return err
+		}
+		if err := writePage(g.Url, sitePage{Page: g.Name, Group: g}); err != nil {
+			return err
+		}
+		for _, api := range g.Apis {
+			if err := writePage(api.Url, sitePage{Page: api.Name, Description: api.Summary, Group: g, Api: api}); err != nil {
+				return err
+			}
+			entries = append(entries, siteSearchEntry{
+				Name:    api.Name,
+				Summary: api.Summary,
+				Group:   g.Name,
+				Routes:  api.Routes,
+				Url:     api.Url,
+				Text:    api.text,
+			})
+		}
+	}
+
+	indexByte, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dest, "search-index.json"), indexByte, 0644); err != nil {
+		return err
+	}
+
+	if d.Conf.SiteUrl == "" {
+		slog.Warn(fmt.Sprintf("%s: `SiteUrl` is empty, `sitemap.xml` is not generated\n", PROJECT_NAME))
+	} else {
+		sitemapByte, err := d.sitemap(pages)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dest, "sitemap.xml"), sitemapByte, 0644); err != nil {
+			return err
+		}
+	}
+
+	if err := copyFolder(
+		filepath.Join(rootPath, "static"), filepath.Join(dest, "static"),
+	); err != nil {
+		return err
+	}
+
+	return
+}
@@ -0,0 +1,81 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownPlainText(t *testing.T) {
+	assert.Equal(t, "request {\"name\": \"xx\"} See the list", markdownPlainText("### request\n\n```json\n{\"name\": \"xx\"}\n```\n\nSee *the* [list](/list)"))
+}
+
+func TestSiteSlug(t *testing.T) {
+	assert.Equal(t, "todo", siteSlug("/todo", "group"))
+	assert.Equal(t, "group", siteSlug("/", "group"))
+
+	used := map[string]bool{"index": true}
+	assert.Equal(t, "index-2", uniqueSlug("index", used))
+	assert.Equal(t, "addtodo", uniqueSlug("addtodo", used))
+	assert.Equal(t, "addtodo-2", uniqueSlug("addtodo", used))
+}
+
+func TestOfflineSite(t *testing.T) {
+	apiDoc := setupCollection()
+	apiDoc.Conf.SiteUrl = "https://docs.example.com/api/"
+
+	out := "test_site"
+	defer os.RemoveAll(out)
+	assert.NoError(t, apiDoc.OfflineSite(out, false))
+	assert.EqualError(t, apiDoc.OfflineSite(out, false), "target `test_site` exists, set `force=true` to override.")
+	assert.NoError(t, apiDoc.OfflineSite(out, true))
+
+	for _, f := range []string{"index.html", "todo/index.html", "todo/addtodo.html", "todo/gettodo.html",
+		"files/index.html", "files/getdata.html", "static/icon/book.svg"} {
+		ok, _ := pathExists(filepath.Join(out, f))
+		assert.True(t, ok, f)
+	}
+
+	// The pages are rendered without JavaScript
+	index, err := os.ReadFile(filepath.Join(out, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "<title>Todo API</title>")
+	assert.Contains(t, string(index), `<a href="todo/addtodo.html">AddTodo</a> - Add todo`)
+	assert.Contains(t, string(index), `href="static/css/github-markdown-1.0.0.min.css"`)
+
+	page, err := os.ReadFile(filepath.Join(out, "todo", "addtodo.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(page), "<title>AddTodo - Todo API</title>")
+	assert.Contains(t, string(page), `<meta name="description" content="Add todo">`)
+	assert.Contains(t, string(page), `<li><code>POST /todo</code></li>`)
+	assert.Contains(t, string(page), `<h3 id="request">request</h3>`)
+	assert.Contains(t, string(page), `<a href="../todo/addtodo.html" class="active">AddTodo</a>`)
+	assert.Contains(t, string(page), `href="../static/css/github-markdown-1.0.0.min.css"`)
+
+	searchByte, err := os.ReadFile(filepath.Join(out, "search-index.json"))
+	assert.NoError(t, err)
+	entries := []siteSearchEntry{}
+	assert.NoError(t, json.Unmarshal(searchByte, &entries))
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "GetData", entries[0].Name)
+	assert.Equal(t, "files/getdata.html", entries[0].Url)
+	assert.Equal(t, "AddTodo", entries[1].Name)
+	assert.Equal(t, "todo", entries[1].Group)
+	assert.Equal(t, []string{"POST /todo"}, entries[1].Routes)
+	assert.Contains(t, entries[1].Text, `"name": "xx"`)
+
+	sitemap, err := os.ReadFile(filepath.Join(out, "sitemap.xml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(sitemap), `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, string(sitemap), "<loc>https://docs.example.com/api/index.html</loc>")
+	assert.Contains(t, string(sitemap), "<loc>https://docs.example.com/api/todo/gettodo.html</loc>")
+
+	// No sitemap without `SiteUrl`
+	apiDoc.Conf.SiteUrl = ""
+	assert.NoError(t, apiDoc.OfflineSite(out, true))
+	ok, _ := pathExists(filepath.Join(out, "sitemap.xml"))
+	assert.False(t, ok)
+}
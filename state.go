@@ -0,0 +1,159 @@
+package gin_docs
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+type stateKey struct {
+	ge   *gin.Engine
+	conf *Config
+}
+
+// docState caches the API data of an engine until its routes change
+type docState struct {
+	mu        sync.Mutex
+	routesKey string
+	dataMap   DataMap
+	// API data by the locales of `Locales`
+	langData map[string]DataMap
+	// Rendered page and `/data` responses by host
+	page *cachedContent
+	data map[string]*cachedContent
+	// Search indexes by version and locale
+	indexes map[string]*searchIndex
+	// Live reload of the open pages
+	rl *reloader
+}
+
+var (
+	stateMu  sync.Mutex
+	stateMap = make(map[stateKey]*docState)
+
+	// Full paths the docs are mounted at, by engine and config
+	mountMu  sync.Mutex
+	mountMap = make(map[stateKey][]string)
+)
+
+func (d ApiDoc) state() *docState {
+	stateMu.Lock()
+	defer stateMu.Unlock()
+
+	key := stateKey{ge: d.Ge, conf: d.Conf.root()}
+	if stateMap[key] == nil {
+		stateMap[key] = &docState{}
+	}
+
+	return stateMap[key]
+}
+
+func (d ApiDoc) addMount(mountPath string) {
+	mountMu.Lock()
+	defer mountMu.Unlock()
+
+	key := stateKey{ge: d.Ge, conf: d.Conf.root()}
+	if !slices.Contains(mountMap[key], mountPath) {
+		mountMap[key] = append(mountMap[key], mountPath)
+	}
+}
+
+func (d ApiDoc) mountPaths() []string {
+	mountMu.Lock()
+	defer mountMu.Unlock()
+
+	return slices.Clone(mountMap[stateKey{ge: d.Ge, conf: d.Conf.root()}])
+}
+
+// routesKey identifies the registered routes, it changes when a route is added
+func (d ApiDoc) routesKey() string {
+	var b strings.Builder
+	for _, r := range d.Ge.Routes() {
+		b.WriteString(r.Method + " " + r.Path + " " + r.Handler + "\n")
+	}
+
+	return b.String()
+}
+
+// apiData returns the API data in the locale, it is computed on first use
+// and again when routes are registered later
+func (d ApiDoc) apiData() DataMap {
+	s := d.state()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return d.loadApiData(s)
+}
+
+// loadApiData must be called with `s.mu` held
+func (d ApiDoc) loadApiData(s *docState) DataMap {
+	routesKey := d.routesKey()
+	if s.dataMap == nil || s.routesKey != routesKey {
+		docMu.Lock()
+		d.getDocData()
+		s.dataMap = ApiDoc{Ge: d.Ge, Conf: d.Conf.root()}.getApiData()
+		docMu.Unlock()
+		s.routesKey = routesKey
+		s.langData = nil
+		s.data = nil
+		s.indexes = nil
+	}
+	if d.Conf.lang == "" {
+		return s.dataMap
+	}
+
+	if s.langData[d.Conf.lang] == nil {
+		docMu.Lock()
+		dataMap := d.getApiData()
+		docMu.Unlock()
+		if s.langData == nil {
+			s.langData = make(map[string]DataMap)
+		}
+		s.langData[d.Conf.lang] = dataMap
+	}
+
+	return s.langData[d.Conf.lang]
+}
+
+func (s *docState) reloader() *reloader {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rl == nil {
+		s.rl = &reloader{clients: make(map[chan string]struct{})}
+	}
+
+	return s.rl
+}
+
+func (s *docState) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.dataMap = nil
+	s.langData = nil
+	s.data = nil
+	s.indexes = nil
+}
+
+func (s *docState) invalidatePage() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.page = nil
+}
+
+// Refresh parses the docs of the handlers again and recomputes the API data
+func (d ApiDoc) Refresh() {
+	docMu.Lock()
+	for _, r := range d.Ge.Routes() {
+		file := handlerFile(r.HandlerFunc)
+		delete(docMap, file)
+		delete(docDeclMap, file)
+	}
+	docMu.Unlock()
+
+	d.state().invalidate()
+}
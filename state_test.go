@@ -0,0 +1,57 @@
+package gin_docs
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+/*
+Get todo
+*/
+func GetTodo(c *gin.Context) {
+	c.JSON(http.StatusOK, nil)
+}
+
+func TestApiDataLateRoutes(t *testing.T) {
+	r := gin.New()
+	r.POST("/get_data", GetData)
+
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.init())
+
+	dataMap := apiDoc.apiData()
+	assert.Len(t, dataMap["gin-docs"]["children"], 1)
+	// Cached until the routes change
+	assert.Equal(t, dataMap["gin-docs"]["children"][0], apiDoc.apiData()["gin-docs"]["children"][0])
+
+	r.GET("/todo", GetTodo)
+	children := apiDoc.apiData()["gin-docs"]["children"]
+	assert.Len(t, children, 2)
+	for _, item := range children {
+		if item["name"] == "GetTodo" {
+			assert.Equal(t, "Get todo", item["name_extra"])
+		}
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	r := gin.New()
+	r.GET("/todo", GetTodo)
+
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.init())
+	assert.Equal(t, "Get todo", apiDoc.apiData()["gin-docs"]["children"][0]["name_extra"])
+
+	file := handlerFile(GetTodo)
+	docMap[file]["GetTodo"] = "Stale todo"
+	apiDoc.state().invalidate()
+	assert.Equal(t, "Stale todo", apiDoc.apiData()["gin-docs"]["children"][0]["name_extra"])
+
+	apiDoc.Refresh()
+	assert.Equal(t, "Get todo", apiDoc.apiData()["gin-docs"]["children"][0]["name_extra"])
+}
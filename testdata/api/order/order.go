@@ -0,0 +1,12 @@
+package order
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// List orders
+func List(c *gin.Context) {
+	c.JSON(http.StatusOK, nil)
+}
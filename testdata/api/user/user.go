@@ -0,0 +1,12 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// List users
+func List(c *gin.Context) {
+	c.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,193 @@
+package gin_docs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+)
+
+// Delimiters of the page template, `{{ }}` are left to Vue
+const (
+	themeLeftDelim  = "[["
+	themeRightDelim = "]]"
+)
+
+// Theme customizes the documentation page, the blocks of the page template `header`,
+// `sidebar`, `endpoint`, `footer` and `theme` can be overridden by `[[define "header"]]...[[end]]`
+type Theme struct {
+	// Directory of the templates overriding the blocks, the `*.html` files are read
+	Dir string `json:"dir" yaml:"dir" toml:"dir"`
+	// Templates overriding the blocks, e.g. an `embed.FS`, read after `Dir`
+	FS fs.FS `json:"-" yaml:"-" toml:"-"`
+	// Url of the logo in the header
+	Logo string `json:"logo" yaml:"logo" toml:"logo"`
+	// Url of the favicon, default `static/icon/book.svg`
+	Favicon string `json:"favicon" yaml:"favicon" toml:"favicon"`
+	// Color of the buttons, links and the selection, e.g. `#409eff`
+	PrimaryColor string `json:"primary_color" yaml:"primary_color" toml:"primary_color"`
+	// Background color of the header
+	HeaderColor string `json:"header_color" yaml:"header_color" toml:"header_color"`
+	// `light`, `dark` or `auto` by the system preference, default `light`
+	DarkMode string `json:"dark_mode" yaml:"dark_mode" toml:"dark_mode"`
+	// CSS added to the page
+	ExtraCss string `json:"extra_css" yaml:"extra_css" toml:"extra_css"`
+	// JavaScript added to the page
+	ExtraJs string `json:"extra_js" yaml:"extra_js" toml:"extra_js"`
+}
+
+var darkModes = []string{"", "light", "dark", "auto"}
+
+var colorRegexp = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|(rgb|hsl)a?\([0-9., %]+\))$`)
+
+// validate returns the errors of the theme
+func (t Theme) validate() []error {
+	errs := []error{}
+	if t.Dir != "" {
+		if info, err := os.Stat(t.Dir); err != nil || !info.IsDir() {
+			errs = append(errs, fmt.Errorf("`Theme.Dir` `%s` is not a directory", t.Dir))
+		}
+	}
+	for _, c := range [][2]string{{"PrimaryColor", t.PrimaryColor}, {"HeaderColor", t.HeaderColor}} {
+		if c[1] != "" && !colorRegexp.MatchString(c[1]) {
+			errs = append(errs, fmt.Errorf("`Theme.%s` `%s` is not a color", c[0], c[1]))
+		}
+	}
+	if !slices.Contains(darkModes, t.DarkMode) {
+		errs = append(errs, fmt.Errorf("`Theme.DarkMode` `%s` must be one of light, dark or auto", t.DarkMode))
+	}
+
+	return errs
+}
+
+// themeFiles returns the templates of the theme directory
+func (t Theme) themeFiles() []string {
+	if t.Dir == "" {
+		return nil
+	}
+	files, _ := filepath.Glob(filepath.Join(t.Dir, "*.html"))
+
+	return files
+}
+
+// pageData is the data of the page template
+type pageData struct {
+	// CSS and JS links, local or from the CDN
+	Css template.HTML
+	Js  template.HTML
+	// Favicon url, default `static/icon/book.svg`
+	Favicon string
+	Theme   Theme
+	// Colors of the theme, the invalid ones are empty
+	PrimaryColor template.CSS
+	HeaderColor  template.CSS
+	ExtraCss     template.CSS
+	ExtraJs      template.JS
+	// Message catalogs by locale as a JSON object
+	Messages template.JS
+	// Headers redacted and body size kept in the exchanges of the debugger, as in the samples
+	RedactHeaders template.JS
+	MaxBodySize   int
+}
+
+// themeColor returns a color of the theme as CSS, or empty if it is not a color
+func themeColor(c string) template.CSS {
+	if !colorRegexp.MatchString(c) {
+		return ""
+	}
+
+	return template.CSS(c)
+}
+
+// parseTheme parses the templates of a theme after the page template, so their blocks override it
+func parseTheme(tmpl *template.Template, fsys fs.FS) error {
+	files, err := fs.Glob(fsys, "*.html")
+	if err != nil || len(files) == 0 {
+		return err
+	}
+	_, err = tmpl.ParseFS(fsys, files...)
+
+	return err
+}
+
+// pageTemplate returns the page template with the blocks of the theme
+func (d ApiDoc) pageTemplate() (*template.Template, error) {
+	tmpl, err := template.New("index").Delims(themeLeftDelim, themeRightDelim).Parse(templateMap["index"])
+	if err != nil {
+		return nil, err
+	}
+
+	theme := d.Conf.Theme
+	if theme.Dir != "" {
+		if err := parseTheme(tmpl, os.DirFS(theme.Dir)); err != nil {
+			return nil, fmt.Errorf("theme `%s`: %s", theme.Dir, err)
+		}
+	}
+	if theme.FS != nil {
+		if err := parseTheme(tmpl, theme.FS); err != nil {
+			return nil, fmt.Errorf("theme: %s", err)
+		}
+	}
+
+	return tmpl, nil
+}
+
+// renderHtml renders the page with the CSS and JS of `Cdn`, the theme and the message catalogs
+func (d ApiDoc) renderHtml() (string, error) {
+	tmpl, err := d.pageTemplate()
+	if err != nil {
+		return "", err
+	}
+
+	data := pageData{
+		Css:          template.HTML(templateMap["css_template_local"]),
+		Js:           template.HTML(templateMap["js_template_local"]),
+		Favicon:      d.Conf.Theme.Favicon,
+		Theme:        d.Conf.Theme,
+		PrimaryColor: themeColor(d.Conf.Theme.PrimaryColor),
+		HeaderColor:  themeColor(d.Conf.Theme.HeaderColor),
+		ExtraCss:     template.CSS(d.Conf.Theme.ExtraCss),
+		ExtraJs:      template.JS(d.Conf.Theme.ExtraJs),
+	}
+	if d.Conf.Cdn {
+		data.Css = template.HTML(templateMap["css_template_cdn"])
+		data.Js = template.HTML(templateMap["js_template_cdn"])
+		if d.Conf.CdnCssTemplate != "" {
+			data.Css = template.HTML(d.Conf.CdnCssTemplate)
+		}
+		if d.Conf.CdnJsTemplate != "" {
+			data.Js = template.HTML(d.Conf.CdnJsTemplate)
+		}
+	}
+	if data.Favicon == "" {
+		data.Favicon = "static/icon/book.svg"
+	}
+	locales, err := d.uiLocales()
+	if err != nil {
+		return "", err
+	}
+	messages, err := json.Marshal(locales)
+	if err != nil {
+		return "", err
+	}
+	data.Messages = template.JS(messages)
+	sc := (&SampleConfig{}).Default()
+	redactHeaders, err := json.Marshal(sc.RedactHeaders)
+	if err != nil {
+		return "", err
+	}
+	data.RedactHeaders = template.JS(redactHeaders)
+	data.MaxBodySize = sc.MaxBodySize
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
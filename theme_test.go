@@ -0,0 +1,89 @@
+package gin_docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTheme(theme Theme) ApiDoc {
+	c := (&Config{}).Default()
+	c.Theme = theme
+
+	return ApiDoc{Ge: gin.New(), Conf: c}
+}
+
+func TestRenderHtml(t *testing.T) {
+	apiDoc := setupTheme(Theme{})
+	assert.NoError(t, apiDoc.init())
+	htmlStr, err := apiDoc.renderHtml()
+	assert.NoError(t, err)
+	assert.Contains(t, htmlStr, `<link rel="icon" href="static/icon/book.svg" type="image/x-icon">`)
+	assert.Contains(t, htmlStr, `<script src="static/js/vue-2.6.14.min.js"></script>`)
+	// The Vue templates are kept
+	assert.Contains(t, htmlStr, `<el-menu-item index="1">{{ titleVersion }}</el-menu-item>`)
+	assert.NotContains(t, htmlStr, "prefers-color-scheme")
+
+	apiDoc = setupTheme(Theme{
+		Logo:         "https://example.com/logo.png",
+		Favicon:      "https://example.com/favicon.ico",
+		PrimaryColor: "#e4393c",
+		HeaderColor:  "rgb(20, 20, 20)",
+		DarkMode:     "auto",
+		ExtraCss:     ".logo { height: 32px; }",
+		ExtraJs:      "console.log(\"partner docs\")",
+	})
+	apiDoc.Conf.Cdn = true
+	htmlStr, err = apiDoc.renderHtml()
+	assert.NoError(t, err)
+	assert.Contains(t, htmlStr, `<img class="logo" src="https://example.com/logo.png" alt="logo">`)
+	assert.Contains(t, htmlStr, `<link rel="icon" href="https://example.com/favicon.ico" type="image/x-icon">`)
+	assert.Contains(t, htmlStr, "https://cdn.staticfile.net/vue/2.6.14/vue.min.js")
+	assert.Contains(t, htmlStr, "border-color: #e4393c;")
+	assert.Contains(t, htmlStr, "background-color: rgb(20, 20, 20);")
+	assert.Contains(t, htmlStr, "@media (prefers-color-scheme: dark) {")
+	assert.Contains(t, htmlStr, "<style>\n    .logo { height: 32px; }\n</style>")
+	assert.Contains(t, htmlStr, "<script>\n    console.log(\"partner docs\")\n</script>")
+}
+
+func TestThemeBlocks(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "footer.html"),
+		[]byte(`[[define "footer"]]<a class="partner" href="https://example.com">Example Inc.</a>[[end]]`), 0644))
+
+	apiDoc := setupTheme(Theme{
+		Dir: dir,
+		FS: fstest.MapFS{
+			"header.html": {Data: []byte(`[[define "header"]]<el-header>Partner API[[with .Theme.Logo]] <img src="[[.]]">[[end]]</el-header>[[end]]`)},
+			"README.md":   {Data: []byte("not a template")},
+		},
+		Logo: "/logo.png",
+	})
+	assert.NoError(t, apiDoc.init())
+	htmlStr, err := apiDoc.renderHtml()
+	assert.NoError(t, err)
+	assert.Contains(t, htmlStr, `<el-header>Partner API <img src="/logo.png"></el-header>`)
+	assert.Contains(t, htmlStr, `<a class="partner" href="https://example.com">Example Inc.</a>`)
+	assert.NotContains(t, htmlStr, "project-name-version\"")
+	// The blocks not overridden are kept
+	assert.Contains(t, htmlStr, `<div id="md"></div>`)
+	assert.Equal(t, []string{filepath.Join(dir, "footer.html")}, apiDoc.Conf.Theme.themeFiles())
+
+	// The docs are not mounted with an invalid template
+	apiDoc.Conf.Theme.FS = fstest.MapFS{"sidebar.html": {Data: []byte(`[[define "sidebar"]][[.Missing]`)}}
+	assert.ErrorContains(t, apiDoc.OnlineHtml(), "theme: template: sidebar.html:1:")
+	assert.Empty(t, apiDoc.Ge.Routes())
+}
+
+func TestThemeValidate(t *testing.T) {
+	c := (&Config{}).Default()
+	c.Theme = Theme{Dir: "missing", PrimaryColor: "red;}", HeaderColor: "#fff", DarkMode: "night"}
+	assert.EqualError(t, c.Validate(), "invalid config:\n"+
+		"`Theme.Dir` `missing` is not a directory\n"+
+		"`Theme.PrimaryColor` `red;}` is not a color\n"+
+		"`Theme.DarkMode` `night` must be one of light, dark or auto")
+}
@@ -0,0 +1,149 @@
+package gin_docs
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"path/filepath"
+	"slices"
+)
+
+// UIs of the docs page
+const (
+	UiVue       = "vue"
+	UiRedoc     = "redoc"
+	UiSwaggerUi = "swagger-ui"
+	UiScalar    = "scalar"
+	UiRapiDoc   = "rapidoc"
+)
+
+var uis = []string{UiVue, UiRedoc, UiSwaggerUi, UiScalar, UiRapiDoc}
+
+// uiAsset is a file of a UI, bundled under `static` and on the CDN
+type uiAsset struct {
+	Local string
+	Cdn   string
+}
+
+// uiBundle is the CSS and JS of a UI
+type uiBundle struct {
+	Css []uiAsset
+	Js  []uiAsset
+}
+
+// uiAssets are the bundles of the UIs rendering the OpenAPI document
+var uiAssets = map[string]uiBundle{
+	UiRedoc: {
+		Js: []uiAsset{{
+			Local: "static/js/redoc-2.1.5.standalone.min.js",
+			Cdn:   "https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js",
+		}},
+	},
+	UiSwaggerUi: {
+		Css: []uiAsset{{
+			Local: "static/css/swagger-ui-5.18.2.min.css",
+			Cdn:   "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.18.2/swagger-ui.css",
+		}},
+		Js: []uiAsset{{
+			Local: "static/js/swagger-ui-bundle-5.18.2.min.js",
+			Cdn:   "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.18.2/swagger-ui-bundle.js",
+		}},
+	},
+	UiScalar: {
+		Js: []uiAsset{{
+			Local: "static/js/scalar-api-reference-1.25.50.min.js",
+			Cdn:   "https://cdn.jsdelivr.net/npm/@scalar/api-reference@1.25.50/dist/browser/standalone.js",
+		}},
+	},
+	UiRapiDoc: {
+		Js: []uiAsset{{
+			Local: "static/js/rapidoc-9.3.8.min.js",
+			Cdn:   "https://cdn.jsdelivr.net/npm/rapidoc@9.3.8/dist/rapidoc-min.js",
+		}},
+	},
+}
+
+// uiPage is the data of the `ui` template
+type uiPage struct {
+	Ui      string
+	Title   string
+	Favicon string
+	// Url of the OpenAPI document relative to the page
+	SpecUrl string
+	Css     []string
+	Js      []string
+}
+
+// isVueUi returns whether the built-in page is served
+func (d ApiDoc) isVueUi() bool {
+	return d.Conf.Ui == "" || d.Conf.Ui == UiVue
+}
+
+// uiBundled returns whether the local copy of an asset is bundled under `static`
+func uiBundled(a uiAsset) bool {
+	ok, _ := pathExists(filepath.Join(rootPath, filepath.FromSlash(a.Local)))
+
+	return ok
+}
+
+// uiUrls returns the urls of the assets of the UI, local or from the CDN by `Cdn`
+func (d ApiDoc) uiUrls(assets []uiAsset) []string {
+	urls := []string{}
+	for _, a := range assets {
+		if d.Conf.Cdn {
+			urls = append(urls, a.Cdn)
+		} else {
+			urls = append(urls, a.Local)
+		}
+	}
+
+	return urls
+}
+
+// checkUiAssets checks that the local assets of the UI are bundled when they are not loaded from the CDN
+func checkUiAssets(ui string, cdn bool) error {
+	if cdn {
+		return nil
+	}
+
+	assets := uiAssets[ui]
+	for _, a := range slices.Concat(assets.Css, assets.Js) {
+		if !uiBundled(a) {
+			return fmt.Errorf("`Ui` `%s` is not bundled at `%s`, set `Cdn` to load it from the CDN", ui, a.Local)
+		}
+	}
+
+	return nil
+}
+
+// renderUi renders the page of a UI rendering the OpenAPI document at `openapi.json`
+func (d ApiDoc) renderUi() (string, error) {
+	tmpl, err := template.New("ui").Parse(templateMap["ui"])
+	if err != nil {
+		return "", err
+	}
+
+	page := uiPage{
+		Ui:      d.Conf.Ui,
+		Title:   d.title(),
+		Favicon: d.Conf.Theme.Favicon,
+		SpecUrl: "openapi.json",
+		Css:     d.uiUrls(uiAssets[d.Conf.Ui].Css),
+		Js:      d.uiUrls(uiAssets[d.Conf.Ui].Js),
+	}
+	if page.Favicon == "" {
+		page.Favicon = "static/icon/book.svg"
+	}
+
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, page); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// validUi returns whether the UI is known
+func validUi(ui string) bool {
+	return ui == "" || slices.Contains(uis, ui)
+}
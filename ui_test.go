@@ -0,0 +1,99 @@
+package gin_docs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func getPage(r *gin.Engine) string {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/", nil)
+	r.ServeHTTP(w, req)
+
+	return w.Body.String()
+}
+
+func TestRenderUi(t *testing.T) {
+	for ui, want := range map[string][]string{
+		UiRedoc: {`<redoc spec-url="openapi.json"></redoc>`, `<script src="https://cdn.jsdelivr.net/npm/redoc@2.1.5/bundles/redoc.standalone.js"></script>`},
+		UiSwaggerUi: {`<div id="swagger-ui"></div>`, `url: "openapi.json",`,
+			`<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5.18.2/swagger-ui.css">`},
+		UiScalar:  {`<script id="api-reference" data-url="openapi.json"></script>`},
+		UiRapiDoc: {`<rapi-doc spec-url="openapi.json"`, `<script type="module" src="https://cdn.jsdelivr.net/npm/rapidoc@9.3.8/dist/rapidoc-min.js"></script>`},
+	} {
+		c := (&Config{Ui: ui, Cdn: true, Title: "Todo API"}).Default()
+		r := gin.New()
+		assert.NoError(t, ApiDoc{Ge: r, Conf: c}.OnlineHtml(), ui)
+
+		page := getPage(r)
+		assert.Contains(t, page, "<title>Todo API</title>", ui)
+		for _, s := range want {
+			assert.Contains(t, page, s, ui)
+		}
+	}
+}
+
+func TestRenderUiLocal(t *testing.T) {
+	// Swagger UI and RapiDoc are bundled
+	for _, ui := range []string{UiSwaggerUi, UiRapiDoc} {
+		c := (&Config{Ui: ui}).Default()
+		r := gin.New()
+		assert.NoError(t, ApiDoc{Ge: r, Conf: c}.OnlineHtml(), ui)
+		page := getPage(r)
+
+		assets := uiAssets[ui]
+		for _, a := range slices.Concat(assets.Css, assets.Js) {
+			assert.True(t, uiBundled(a), a.Local)
+			assert.Contains(t, page, `="`+a.Local+`"`, ui)
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/docs/api/"+a.Local, nil)
+			r.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusOK, w.Code, a.Local)
+		}
+	}
+	c := (&Config{Ui: UiRapiDoc}).Default()
+	r := gin.New()
+	assert.NoError(t, ApiDoc{Ge: r, Conf: c}.OnlineHtml())
+	assert.Contains(t, getPage(r), `<script type="module" src="static/js/rapidoc-9.3.8.min.js"></script>`)
+
+	// The docs are not mounted without the local copy, instead of loading it from the network
+	saved := uiAssets[UiSwaggerUi]
+	uiAssets[UiSwaggerUi] = uiBundle{Js: []uiAsset{{Local: "static/js/missing.js", Cdn: "https://cdn.example.com/missing.js"}}}
+	defer func() { uiAssets[UiSwaggerUi] = saved }()
+	r = gin.New()
+	assert.EqualError(t, ApiDoc{Ge: r, Conf: (&Config{Ui: UiSwaggerUi}).Default()}.OnlineHtml(),
+		"`Ui` `swagger-ui` is not bundled at `static/js/missing.js`, set `Cdn` to load it from the CDN")
+	assert.Empty(t, r.Routes())
+	r = gin.New()
+	assert.NoError(t, ApiDoc{Ge: r, Conf: (&Config{Ui: UiSwaggerUi, Cdn: true}).Default()}.OnlineHtml())
+	assert.Contains(t, getPage(r), `<script src="https://cdn.example.com/missing.js"></script>`)
+}
+
+func TestUiValidate(t *testing.T) {
+	c := (&Config{Ui: "elements"}).Default()
+	assert.EqualError(t, c.Validate(), "invalid config:\n"+
+		"`Ui` `elements` must be one of vue, redoc, swagger-ui, scalar, rapidoc")
+
+	c.Ui = UiRedoc
+	c.Cdn = true
+	c.PasswordSha2 = "8c6976e5b5410415bde908bd4dee15dfb167a9c873fc4bb8a81f6f2ab448a918"
+	assert.EqualError(t, c.Validate(), "invalid config:\n"+
+		"`Ui` `redoc` can not send the password of `PasswordSha2`, use `vue`")
+
+	// Redoc and Scalar are loaded from the CDN until their copies are added by `make ui-assets`
+	for _, ui := range []string{UiRedoc, UiScalar} {
+		c = (&Config{Ui: ui}).Default()
+		if uiBundled(uiAssets[ui].Js[0]) {
+			assert.NoError(t, c.Validate(), ui)
+			continue
+		}
+		assert.ErrorContains(t, c.Validate(), "`Ui` `"+ui+"` is not bundled", ui)
+		c.Cdn = true
+		assert.NoError(t, c.Validate(), ui)
+	}
+}
@@ -0,0 +1,391 @@
+package gin_docs
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"log/slog"
+	"math"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Model declares the typed request and response of a handler,
+// the `json` examples of its doc are validated against them
+type Model struct {
+	Handler  gin.HandlerFunc
+	Request  any
+	Response any
+}
+
+type ExampleError struct {
+	Pos     token.Position
+	Handler string
+	// `request` or `response`
+	Section string
+	Message string
+}
+
+func (e ExampleError) Error() string {
+	return fmt.Sprintf("%s: %s %s example: %s", e.Pos, e.Handler, e.Section, e.Message)
+}
+
+type jsonExample struct {
+	section string
+	pos     token.Position
+	body    string
+}
+
+// jsonExamples returns the `json` fenced blocks under the request and response headings
+func jsonExamples(lines []docLine) []jsonExample {
+	examples := []jsonExample{}
+	section := ""
+	var example *jsonExample
+	for _, l := range lines {
+		text := strings.TrimSpace(l.Text)
+		if example != nil {
+			if strings.HasPrefix(text, "```") {
+				examples = append(examples, *example)
+				example = nil
+			} else {
+				example.body += l.Text + "\n"
+			}
+			continue
+		}
+
+		if strings.HasPrefix(text, "```") {
+			if strings.EqualFold(strings.TrimSpace(strings.TrimPrefix(text, "```")), "json") &&
+				section != "" {
+				example = &jsonExample{section: section, pos: l.Pos}
+			}
+			continue
+		}
+		if m := headingRegexp.FindStringSubmatch(text); m != nil {
+			section = ""
+			for _, name := range []string{"request", "response"} {
+				if slices.Contains(sectionAliases[name], strings.ToLower(m[2])) {
+					section = name
+				}
+			}
+		}
+	}
+
+	return examples
+}
+
+// ValidateExamples checks that the `json` request and response examples of the
+// handlers declared in `Config.Models` are valid JSON conforming to their types,
+// a handler which is not a registered route is an error
+func (d ApiDoc) ValidateExamples() ([]ExampleError, error) {
+	if err := d.init(); err != nil {
+		return nil, err
+	}
+
+	routes := []string{}
+	for _, r := range d.Ge.Routes() {
+		routes = append(routes, r.Handler)
+	}
+	for i, m := range d.Conf.Models {
+		if m.Handler == nil {
+			return nil, fmt.Errorf("`Models[%d]` has no handler", i)
+		}
+		if name := handlerName(m.Handler); !slices.Contains(routes, name) {
+			return nil, fmt.Errorf("`Models` handler `%s` is not a registered route", name)
+		}
+	}
+
+	errs := []ExampleError{}
+	docMu.RLock()
+	defer docMu.RUnlock()
+	for _, m := range d.Conf.Models {
+		_, funcName := d.splitHandler(handlerName(m.Handler))
+		decl := docDeclMap[handlerFile(m.Handler)][funcName]
+		if decl.fset == nil {
+			continue
+		}
+
+		for _, example := range jsonExamples(commentLines(decl.fset, decl.doc)) {
+			model := m.Request
+			if example.section == "response" {
+				model = m.Response
+			}
+			if model == nil {
+				continue
+			}
+
+			for _, msg := range validateExample(example.body, reflect.TypeOf(model)) {
+				errs = append(errs, ExampleError{
+					Pos:     example.pos,
+					Handler: funcName,
+					Section: example.section,
+					Message: msg,
+				})
+			}
+		}
+	}
+
+	return errs, nil
+}
+
+// RequireValidExamples fails the test when any example does not conform to its model
+func RequireValidExamples(t TestingT, d ApiDoc) {
+	t.Helper()
+
+	errs, err := d.ValidateExamples()
+	if err != nil {
+		t.Errorf("%s", err)
+		t.FailNow()
+		return
+	}
+	if len(errs) > 0 {
+		msgs := []string{}
+		for _, e := range errs {
+			msgs = append(msgs, e.Error())
+		}
+		t.Errorf("invalid examples:\n%s", strings.Join(msgs, "\n"))
+		t.FailNow()
+	}
+}
+
+func (d ApiDoc) logInvalidExamples() {
+	errs, err := d.ValidateExamples()
+	if err != nil {
+		slog.Error(fmt.Sprintf("%s err: %s\n", PROJECT_NAME, err))
+		return
+	}
+	for _, e := range errs {
+		slog.Warn(fmt.Sprintf("%s invalid example: %s\n", PROJECT_NAME, e))
+	}
+}
+
+func validateExample(body string, t reflect.Type) []string {
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %s", err)}
+	}
+
+	msgs := []string{}
+	validateValue(v, t, "", &msgs)
+
+	return msgs
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func jsonTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+func validateValue(v any, t reflect.Type, path string, msgs *[]string) {
+	at := path
+	if at == "" {
+		at = "body"
+	}
+	mismatch := func(want string) {
+		*msgs = append(*msgs, fmt.Sprintf("`%s` is %s, want %s", at, jsonTypeName(v), want))
+	}
+
+	if v == nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map, reflect.Interface:
+		default:
+			mismatch(t.String())
+		}
+		return
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if t == timeType {
+		if s, ok := v.(string); !ok {
+			mismatch("date-time string")
+		} else if _, err := time.Parse(time.RFC3339, s); err != nil {
+			*msgs = append(*msgs, fmt.Sprintf("`%s` is not an RFC 3339 date-time", at))
+		}
+		return
+	}
+	// Custom formats can not be checked
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return
+	}
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		if _, ok := v.(string); !ok {
+			mismatch("string")
+		}
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+	case reflect.Bool:
+		if _, ok := v.(bool); !ok {
+			mismatch("boolean")
+		}
+	case reflect.String:
+		if _, ok := v.(string); !ok {
+			mismatch("string")
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if n, ok := v.(float64); !ok || n != math.Trunc(n) {
+			mismatch("integer")
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := v.(float64); !ok {
+			mismatch("number")
+		}
+	case reflect.Slice, reflect.Array:
+		// []byte is a base64 string
+		if t.Elem().Kind() == reflect.Uint8 {
+			if _, ok := v.(string); !ok {
+				mismatch("base64 string")
+			}
+			return
+		}
+		items, ok := v.([]any)
+		if !ok {
+			mismatch("array")
+			return
+		}
+		for i, item := range items {
+			validateValue(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i), msgs)
+		}
+	case reflect.Map:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			mismatch("object")
+			return
+		}
+		for _, k := range sortedKeys(obj) {
+			validateValue(obj[k], t.Elem(), joinPath(path, k), msgs)
+		}
+	case reflect.Struct:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			mismatch("object")
+			return
+		}
+		fields := jsonFields(t)
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		present := map[string]bool{}
+		for _, k := range sortedKeys(obj) {
+			name, ok := fieldName(names, k)
+			if !ok {
+				*msgs = append(*msgs, fmt.Sprintf("unknown field `%s`", joinPath(path, k)))
+				continue
+			}
+			present[name] = true
+			validateValue(obj[k], fields[name].Type, joinPath(path, k), msgs)
+		}
+		for _, name := range names {
+			if !present[name] && fields[name].required {
+				*msgs = append(*msgs, fmt.Sprintf("missing required field `%s`", joinPath(path, name)))
+			}
+		}
+	}
+}
+
+// fieldName returns the field of a JSON key, the names are sorted and
+// match case-insensitively like encoding/json when none is exact
+func fieldName(names []string, k string) (string, bool) {
+	if slices.Contains(names, k) {
+		return k, true
+	}
+	for _, name := range names {
+		if strings.EqualFold(name, k) {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+type jsonField struct {
+	Type     reflect.Type
+	required bool
+}
+
+// jsonFields returns the fields of a struct by JSON name, embedded structs are flattened
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			for k, v := range jsonFields(ft) {
+				if _, ok := fields[k]; !ok {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		fields[name] = jsonField{
+			Type:     f.Type,
+			required: slices.Contains(strings.Split(f.Tag.Get("binding"), ","), "required"),
+		}
+	}
+
+	return fields
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func joinPath(path, k string) string {
+	if path == "" {
+		return k
+	}
+
+	return path + "." + k
+}
@@ -0,0 +1,120 @@
+package gin_docs
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type todoBase struct {
+	Id int64 `json:"id"`
+}
+
+type todoRequest struct {
+	todoBase
+	Name string    `json:"name" binding:"required"`
+	Type string    `json:"type" binding:"required,oneof=code life"`
+	Tags []string  `json:"tags"`
+	Due  time.Time `json:"due"`
+	Note *string   `json:"note"`
+	Skip string    `json:"-"`
+}
+
+type todoResponse struct {
+	Code int            `json:"code"`
+	Msg  string         `json:"msg"`
+	Data map[string]any `json:"data"`
+}
+
+/*
+Add todo
+
+### request
+```json
+{"id": 1.5, "name": "xx", "tags": ["a", 1], "due": "tomorrow", "note": null, "Skip": "x"}
+```
+
+### response
+```json
+{"code": xxxx, "msg": "xxx", "data": null}
+```
+*/
+func AddTodo(c *gin.Context) {
+	c.JSON(http.StatusOK, nil)
+}
+
+func TestValidateExamples(t *testing.T) {
+	r := gin.New()
+	r.POST("/todo", AddTodo)
+
+	c := (&Config{}).Default()
+	c.Models = []Model{{Handler: AddTodo, Request: todoRequest{}, Response: &todoResponse{}}}
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+
+	errs, err := apiDoc.ValidateExamples()
+	assert.NoError(t, err)
+
+	msgs := []string{}
+	for _, e := range errs {
+		msgs = append(msgs, e.Section+": "+e.Message)
+		assert.Equal(t, "AddTodo", e.Handler)
+		assert.Equal(t, "validate_test.go", e.Pos.Filename[len(e.Pos.Filename)-len("validate_test.go"):])
+	}
+	assert.Equal(t, []string{
+		"request: unknown field `Skip`",
+		"request: `due` is not an RFC 3339 date-time",
+		"request: `id` is number, want integer",
+		"request: `tags[1]` is number, want string",
+		"request: missing required field `type`",
+		"response: invalid JSON: invalid character 'x' looking for beginning of value",
+	}, msgs)
+	assert.Equal(t, 37, errs[0].Pos.Line)
+	assert.Equal(t, 42, errs[len(errs)-1].Pos.Line)
+
+	ft := &fakeT{}
+	RequireValidExamples(ft, apiDoc)
+	assert.True(t, ft.failed)
+	assert.Contains(t, ft.errors[0], "AddTodo response example: invalid JSON")
+}
+
+func TestValidateExamplesValid(t *testing.T) {
+	r := gin.New()
+	r.POST("/get_data", GetData)
+
+	c := (&Config{}).Default()
+	c.Models = []Model{{Handler: GetData, Response: todoResponse{}}}
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+
+	ft := &fakeT{}
+	RequireValidExamples(ft, apiDoc)
+	assert.False(t, ft.failed)
+}
+
+func TestValidateExampleFieldCase(t *testing.T) {
+	msgs := validateExample(`{"Name": "xx", "TYPE": "code", "Ids": 1}`, reflect.TypeOf(todoRequest{}))
+	assert.Equal(t, []string{"unknown field `Ids`"}, msgs)
+}
+
+func TestValidateExamplesUnregistered(t *testing.T) {
+	r := gin.New()
+	r.POST("/get_data", GetData)
+
+	c := (&Config{}).Default()
+	c.Models = []Model{{Handler: GetData}, {Handler: AddTodo, Request: todoRequest{}}}
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+
+	_, err := apiDoc.ValidateExamples()
+	assert.EqualError(t, err, "`Models` handler `github.com/kwkwc/gin-docs.AddTodo` is not a registered route")
+
+	c.Models = []Model{{Request: todoRequest{}}}
+	_, err = apiDoc.ValidateExamples()
+	assert.EqualError(t, err, "`Models[0]` has no handler")
+
+	ft := &fakeT{}
+	RequireValidExamples(ft, apiDoc)
+	assert.True(t, ft.failed)
+}
@@ -0,0 +1,326 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DocVersion is an API version of the version switcher, the routes are
+// those of the live engine, or those of an archived data file
+type DocVersion struct {
+	Name string `json:"name" yaml:"name" toml:"name"`
+	// Only the routes under the prefix, e.g. `/v1`
+	PathPrefix string `json:"path_prefix" yaml:"path_prefix" toml:"path_prefix"`
+	// `data` file exported by `OfflineHtml`
+	DataFile string `json:"data_file" yaml:"data_file" toml:"data_file"`
+}
+
+// VersionChange is a route added, removed or changed between two versions
+type VersionChange struct {
+	Method string `json:"method"`
+	// Path without the `PathPrefix` of the version
+	Path string `json:"path"`
+	Name string `json:"name"`
+	// Line diff of the docs of a changed route
+	Diff string `json:"diff,omitempty"`
+}
+
+type VersionDiff struct {
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Added   []VersionChange `json:"added"`
+	Removed []VersionChange `json:"removed"`
+	Changed []VersionChange `json:"changed"`
+}
+
+// snapshot is an exported data file read at the modification time of the file
+type snapshot struct {
+	modTime time.Time
+	data    DataMap
+}
+
+var snapshotMap sync.Map
+
+// findVersion returns the version by name, the first version when name is empty
+func (d ApiDoc) findVersion(name string) (DocVersion, error) {
+	if len(d.Conf.Versions) == 0 {
+		if name == "" || name == d.Conf.Version {
+			return DocVersion{Name: d.Conf.Version}, nil
+		}
+	} else if name == "" {
+		return d.Conf.Versions[0], nil
+	}
+
+	for _, v := range d.Conf.Versions {
+		if v.Name == name {
+			return v, nil
+		}
+	}
+
+	return DocVersion{}, fmt.Errorf("version `%s` does not exist", name)
+}
+
+func (d ApiDoc) versionNames() []string {
+	names := []string{}
+	for _, v := range d.Conf.Versions {
+		names = append(names, v.Name)
+	}
+
+	return names
+}
+
+// loadSnapshot reads an exported data file, it is read again once it is modified
+func loadSnapshot(file string) (DataMap, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := snapshotMap.Load(file); ok && v.(snapshot).modTime.Equal(info.ModTime()) {
+		return v.(snapshot).data, nil
+	}
+
+	dataByte, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	exported := struct {
+		Data DataMap `json:"data"`
+	}{}
+	if err := json.Unmarshal(dataByte, &exported); err != nil {
+		return nil, fmt.Errorf("invalid data file `%s`: %s", file, err)
+	}
+	if exported.Data == nil {
+		exported.Data = DataMap{}
+	}
+	snapshotMap.Store(file, snapshot{modTime: info.ModTime(), data: exported.Data})
+
+	return exported.Data, nil
+}
+
+func hasPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// filterPrefix keeps the routes under the prefix
+func filterPrefix(dataMap DataMap, prefix string) DataMap {
+	newDataMap := DataMap{}
+	for k, rm := range dataMap {
+		children := []KVMap{}
+		for _, item := range rm["children"] {
+			urls, methods := []string{}, []string{}
+			for _, r := range apiRoutes(item) {
+				if !hasPathPrefix(r.Path, prefix) {
+					continue
+				}
+				urls = append(urls, fmt.Sprintf("%s\t[%s]", r.Path, r.Method))
+				if !slices.Contains(methods, r.Method) {
+					methods = append(methods, r.Method)
+				}
+			}
+			if len(urls) == 0 {
+				continue
+			}
+
+			newItem := KVMap{}
+			for field, value := range item {
+				newItem[field] = value
+			}
+			sort.Strings(urls)
+			sort.Strings(methods)
+			newItem["url"], newItem["method"] = strings.Join(urls, " "), strings.Join(methods, " ")
+			children = append(children, newItem)
+		}
+		if len(children) > 0 {
+			newDataMap[k] = RouterMap{"children": children}
+		}
+	}
+
+	return newDataMap
+}
+
+// versionData returns the API data of a version, `live` is the data of the engine
+func (d ApiDoc) versionData(v DocVersion, live DataMap) (DataMap, error) {
+	dataMap := live
+	if v.DataFile != "" {
+		snapshot, err := loadSnapshot(v.DataFile)
+		if err != nil {
+			return nil, err
+		}
+		dataMap = snapshot
+	}
+	if v.PathPrefix != "" {
+		dataMap = filterPrefix(dataMap, v.PathPrefix)
+	}
+
+	return dataMap, nil
+}
+
+type versionRoute struct {
+	route apiRoute
+	item  KVMap
+}
+
+// versionRoutes returns the routes of a version by `METHOD path`, the path is relative to `PathPrefix`
+func (d ApiDoc) versionRoutes(name string) (DocVersion, map[string]versionRoute, error) {
+	v, err := d.findVersion(name)
+	if err != nil {
+		return v, nil, err
+	}
+	dataMap, err := d.versionData(v, d.apiData())
+	if err != nil {
+		return v, nil, err
+	}
+
+	routes := map[string]versionRoute{}
+	for _, rm := range dataMap {
+		for _, item := range rm["children"] {
+			for _, r := range apiRoutes(item) {
+				r.Path = strings.TrimPrefix(r.Path, strings.TrimSuffix(v.PathPrefix, "/"))
+				if r.Path == "" {
+					r.Path = "/"
+				}
+				routes[r.Method+" "+r.Path] = versionRoute{route: r, item: item}
+			}
+		}
+	}
+
+	return v, routes, nil
+}
+
+// Diff compares the routes and docs of two versions, `to` is the first version when empty
+func (d ApiDoc) Diff(from, to string) (VersionDiff, error) {
+	fromV, fromRoutes, err := d.versionRoutes(from)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+	toV, toRoutes, err := d.versionRoutes(to)
+	if err != nil {
+		return VersionDiff{}, err
+	}
+
+	diff := VersionDiff{
+		From:    fromV.Name,
+		To:      toV.Name,
+		Added:   []VersionChange{},
+		Removed: []VersionChange{},
+		Changed: []VersionChange{},
+	}
+	change := func(vr versionRoute) VersionChange {
+		return VersionChange{Method: vr.route.Method, Path: vr.route.Path, Name: vr.item["name"]}
+	}
+	for key, vr := range toRoutes {
+		old, ok := fromRoutes[key]
+		if !ok {
+			diff.Added = append(diff.Added, change(vr))
+			continue
+		}
+		if before, after := itemDocText(old.item), itemDocText(vr.item); before != after {
+			c := change(vr)
+			c.Diff = lineDiff(before, after)
+			diff.Changed = append(diff.Changed, c)
+		}
+	}
+	for key, vr := range fromRoutes {
+		if _, ok := toRoutes[key]; !ok {
+			diff.Removed = append(diff.Removed, change(vr))
+		}
+	}
+	for _, changes := range [][]VersionChange{diff.Added, diff.Removed, diff.Changed} {
+		sort.Slice(changes, func(i, j int) bool {
+			if changes[i].Path != changes[j].Path {
+				return changes[i].Path < changes[j].Path
+			}
+			return changes[i].Method < changes[j].Method
+		})
+	}
+
+	return diff, nil
+}
+
+// Markdown renders the diff for the page
+func (vd VersionDiff) Markdown() string {
+	md := fmt.Sprintf("# Changes from `%s` to `%s`\n\n", vd.From, vd.To)
+	if len(vd.Added)+len(vd.Removed)+len(vd.Changed) == 0 {
+		return md + "No changes\n"
+	}
+
+	for _, section := range []struct {
+		title   string
+		changes []VersionChange
+	}{{"Added", vd.Added}, {"Removed", vd.Removed}, {"Changed", vd.Changed}} {
+		if len(section.changes) == 0 {
+			continue
+		}
+		md += "## " + section.title + "\n\n"
+		for _, c := range section.changes {
+			md += fmt.Sprintf("- `%s %s` %s\n", c.Method, c.Path, c.Name)
+		}
+		md += "\n"
+		for _, c := range section.changes {
+			if c.Diff != "" {
+				fence := codeFence(c.Diff)
+				md += fmt.Sprintf("### `%s %s`\n\n%sdiff\n%s%s\n\n", c.Method, c.Path, fence, c.Diff, fence)
+			}
+		}
+	}
+
+	return md
+}
+
+func itemDocText(item KVMap) string {
+	parts := []string{}
+	for _, field := range []string{"name_extra", "doc", "doc_md"} {
+		if text := strings.TrimSpace(item[field]); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+// lineDiff returns the lines removed with `-` and added with `+` from before to after
+func lineDiff(before, after string) string {
+	a, b := strings.Split(before, "\n"), strings.Split(after, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			sb.WriteString(" " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("-" + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+" + b[j] + "\n")
+			j++
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,150 @@
+package gin_docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupVersions(t *testing.T) (*gin.Engine, ApiDoc) {
+	r := gin.New()
+	v1 := r.Group("/v1")
+	v1.POST("/add_data", AddData)
+	v1.DELETE("/delete_data", DeleteData)
+	v2 := r.Group("/v2")
+	v2.POST("/add_data", AddData)
+	v2.PUT("/change_data", ChangeData)
+
+	c := (&Config{}).Default()
+	c.Versions = []DocVersion{
+		{Name: "v2", PathPrefix: "/v2"},
+		{Name: "v1", PathPrefix: "/v1"},
+	}
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.OnlineHtml())
+
+	return r, apiDoc
+}
+
+func getVersionData(r *gin.Engine, version string) (int, map[string]any) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/data?version="+version, nil)
+	r.ServeHTTP(w, req)
+
+	data := map[string]any{}
+	json.Unmarshal(w.Body.Bytes(), &data)
+
+	return w.Code, data
+}
+
+func TestVersionData(t *testing.T) {
+	r, _ := setupVersions(t)
+
+	code, data := getVersionData(r, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "v2", data["version"])
+	assert.Equal(t, []any{"v2", "v1"}, data["versions"])
+	assert.Contains(t, dataJson(data), "/v2/change_data")
+	assert.NotContains(t, dataJson(data), "/v1/")
+
+	code, data = getVersionData(r, "v1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "v1", data["version"])
+	assert.Contains(t, dataJson(data), "/v1/delete_data")
+	assert.NotContains(t, dataJson(data), "/v2/")
+
+	code, data = getVersionData(r, "v3")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "version `v3` does not exist", data["error"])
+}
+
+func dataJson(data map[string]any) string {
+	b, _ := json.Marshal(data["data"])
+
+	return string(b)
+}
+
+func TestVersionDiff(t *testing.T) {
+	r, apiDoc := setupVersions(t)
+
+	diff, err := apiDoc.Diff("v1", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", diff.From)
+	assert.Equal(t, "v2", diff.To)
+	assert.Equal(t, []VersionChange{{Method: "PUT", Path: "/change_data", Name: "ChangeData"}}, diff.Added)
+	assert.Equal(t, []VersionChange{{Method: "DELETE", Path: "/delete_data", Name: "DeleteData"}}, diff.Removed)
+	assert.Empty(t, diff.Changed)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/docs/api/diff?from=v1&to=v2&format=md", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.True(t, strings.HasPrefix(w.Body.String(), "# Changes from `v1` to `v2`"))
+	assert.Contains(t, w.Body.String(), "## Added\n\n- `PUT /change_data` ChangeData\n")
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/docs/api/diff?from=v1&to=v2&format=html", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "<li><code>PUT /change_data</code> ChangeData</li>")
+
+	_, err = apiDoc.Diff("v0", "")
+	assert.Error(t, err)
+}
+
+func TestVersionSnapshot(t *testing.T) {
+	r := gin.New()
+	r.POST("/add_data", AddData)
+	c := (&Config{}).Default()
+	apiDoc := ApiDoc{Ge: r, Conf: c}
+	assert.NoError(t, apiDoc.init())
+
+	dataMap := apiDoc.getApiData()
+	item := dataMap["gin-docs"]["children"][0]
+	item["doc_md"] = "Old doc\n```json\n{}\n```\n" + item["doc_md"]
+	snapshot := map[string]any{"version": "0.9", "data": dataMap}
+	dataByte, _ := json.Marshal(snapshot)
+	file := filepath.Join(t.TempDir(), "data")
+	assert.NoError(t, os.WriteFile(file, dataByte, 0644))
+
+	c.Versions = []DocVersion{{Name: "1.0"}, {Name: "0.9", DataFile: file}}
+	r.PUT("/change_data", ChangeData)
+
+	diff, err := apiDoc.Diff("0.9", "1.0")
+	assert.NoError(t, err)
+	assert.Len(t, diff.Added, 1)
+	assert.Empty(t, diff.Removed)
+	assert.Len(t, diff.Changed, 1)
+	assert.Equal(t, "/add_data", diff.Changed[0].Path)
+	assert.Contains(t, diff.Changed[0].Diff, "\n-Old doc\n")
+	// The fence is longer than the fences of the docs
+	assert.Contains(t, diff.Changed[0].Diff, "```")
+	assert.Contains(t, diff.Markdown(), "````diff\n")
+
+	// An edited data file is read again
+	item["doc_md"] = strings.Replace(item["doc_md"], "Old doc", "Older doc", 1)
+	dataByte, _ = json.Marshal(snapshot)
+	assert.NoError(t, os.WriteFile(file, dataByte, 0644))
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(file, later, later))
+	diff, err = apiDoc.Diff("0.9", "1.0")
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Changed[0].Diff, "\n-Older doc\n")
+
+	c.Versions = append(c.Versions, DocVersion{Name: "broken", DataFile: filepath.Join(t.TempDir(), "missing")})
+	_, err = apiDoc.Diff("broken", "")
+	assert.Error(t, err)
+}
+
+func TestLineDiff(t *testing.T) {
+	assert.Equal(t, " a\n-b\n+c\n d\n+e\n", lineDiff("a\nb\nd", "a\nc\nd\ne"))
+	assert.Equal(t, " a\n", lineDiff("a", "a"))
+}